@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,21 +21,54 @@ import (
 // RunGit launches the git status TUI, or prints a non-interactive list with
 // -l (status), -c (commits), or -s (stash).
 func RunGit() error {
-	for _, arg := range os.Args[2:] {
-		switch arg {
-		case "-l", "--list":
-			return runGitList()
-		case "-c", "--commits":
-			return runGitCommitList()
-		case "-s", "--stash":
-			return runGitStashList()
+	args := os.Args[2:]
+	// lz g prompt takes lz-prompt's flags, and none of lz g's.
+	if len(args) > 0 && args[0] == "prompt" {
+		return runGitPrompt(args[1:])
+	}
+	opts, err := parseGitArgs(args)
+	if err != nil {
+		return err
+	}
+	if opts.cached {
+		switch opts.mode {
+		case modeTUI:
+			opts.mode = modeList
+		case modeList:
+		default:
+			return fmt.Errorf("--cached works with -l, --json and --ndjson")
 		}
 	}
-
-	m, err := initialGitModel()
+	if opts.format != formatText {
+		switch opts.mode {
+		case modeTUI, modeList, modeCommits, modeStash:
+			return runGitJSON(opts)
+		}
+		return fmt.Errorf("--json and --ndjson work with -l, -c and -s")
+	}
+	if opts.watch && opts.mode != modeTUI {
+		return fmt.Errorf("--watch works with the TUI only")
+	}
+	switch opts.mode {
+	case modeList:
+		return runGitList(opts)
+	case modeCommits:
+		return runGitCommitList(opts)
+	case modeStash:
+		return runGitStashList(opts)
+	case modeLog:
+		return runGitLog(opts)
+	case modeReport:
+		return runGitReport(opts)
+	case modeChangelog:
+		return runGitChangelog(opts)
+	}
+
+	m, err := initialGitModel(opts)
 	if err != nil {
 		return err
 	}
+	initDiffTheme()
 	_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
 	return err
 }
@@ -43,15 +77,305 @@ func RunGit() error {
 
 const defaultHistoryLimit = 5
 
+type gitMode int
+
+const (
+	modeTUI gitMode = iota
+	modeList
+	modeCommits
+	modeStash
+	modeLog
+	modeReport
+	modeChangelog
+)
+
+// gitOptions holds lz g settings from flags and the lz.* git config.
+type gitOptions struct {
+	mode     gitMode
+	limit    int           // commits per repo, and per "load more" page in the TUI
+	filter   git.LogFilter // commit search; repos without matches are hidden
+	output   string        // lz g report, changelog: write to this file instead of stdout
+	repo     string        // lz g changelog: only this repo (by name)
+	from     string        // lz g changelog: start ref (default: the latest tag before to)
+	to       string        // lz g changelog: end ref (default HEAD)
+	graph    bool          // TUI Commits tab: draw git's --graph lanes
+	branches bool          // TUI Commits tab: all local branches, not just HEAD (not with --range)
+	format   outputFormat  // -l, -c, -s: text, --json or --ndjson
+	watch    bool          // TUI: refresh repos as they change
+	cached   bool          // -l, --json: use cached statuses instead of reading repos
+}
+
+// subcommands are the words lz g takes as its first argument, besides
+// prompt (see RunGit).
+var subcommands = map[string]gitMode{
+	"log":       modeLog,
+	"report":    modeReport,
+	"changelog": modeChangelog,
+}
+
+// parseGitArgs reads lz g flags. The history limit comes from -n, then the
+// lz.historyLimit git config, then defaultHistoryLimit (defaultTimelineLimit
+// for lz g log and lz g report).
+func parseGitArgs(args []string) (gitOptions, error) {
+	opts := gitOptions{limit: defaultHistoryLimit}
+	limitSet := false
+	positional := 0
+	sub, subMode, modeFlag := "", modeTUI, ""
+
+	for i := 0; i < len(args); i++ {
+		name, val, hasVal := strings.Cut(args[i], "=")
+		value := func() (string, error) {
+			if hasVal {
+				return val, nil
+			}
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("%s needs a value", name)
+			}
+			i++
+			return args[i], nil
+		}
+
+		switch name {
+		case "--from", "--to":
+			v, err := value()
+			if err != nil {
+				return opts, err
+			}
+			if name == "--from" {
+				opts.from = v
+			} else {
+				opts.to = v
+			}
+		case "-o", "--output":
+			v, err := value()
+			if err != nil {
+				return opts, err
+			}
+			opts.output = v
+		case "-l", "--list":
+			opts.mode, modeFlag = modeList, name
+		case "--json":
+			opts.format = formatJSON
+		case "--ndjson":
+			opts.format = formatNDJSON
+		case "-w", "--watch":
+			opts.watch = true
+		case "--cached":
+			opts.cached = true
+		case "-c", "--commits":
+			opts.mode, modeFlag = modeCommits, name
+		case "-s", "--stash":
+			opts.mode, modeFlag = modeStash, name
+		case "-n", "--limit":
+			v, err := value()
+			if err != nil {
+				return opts, err
+			}
+			if opts.limit, err = parseLimit(v); err != nil {
+				return opts, fmt.Errorf("%s: %w", name, err)
+			}
+			limitSet = true
+		case "--author", "--since", "--until", "--grep", "--path", "--range":
+			v, err := value()
+			if err != nil {
+				return opts, err
+			}
+			if name == "--since" || name == "--until" {
+				v = gitDate(v, time.Now())
+			}
+			if name == "--range" && strings.HasPrefix(v, "-") {
+				// git log would take it as one of its own options.
+				return opts, fmt.Errorf("--range: not a revision range: %q", v)
+			}
+			*filterField(&opts.filter, name) = v
+		default:
+			if strings.HasPrefix(name, "-") {
+				return opts, fmt.Errorf("unknown flag: %s", args[i])
+			}
+			// Only the first word names a subcommand; changelog takes a
+			// repo name after it.
+			positional++
+			switch m, ok := subcommands[args[i]]; {
+			case positional == 1 && ok:
+				sub, subMode = args[i], m
+			case positional == 2 && subMode == modeChangelog:
+				opts.repo = args[i]
+			default:
+				return opts, fmt.Errorf("unexpected argument: %s", args[i])
+			}
+		}
+	}
+	if sub != "" {
+		if modeFlag != "" {
+			return opts, fmt.Errorf("%s: not with lz g %s", modeFlag, sub)
+		}
+		opts.mode = subMode
+	}
+	for _, f := range []struct {
+		flag string
+		set  bool
+		ok   bool
+		use  string
+	}{
+		{"--from", opts.from != "", opts.mode == modeChangelog, "lz g changelog"},
+		{"--to", opts.to != "", opts.mode == modeChangelog, "lz g changelog"},
+		{"-o", opts.output != "", opts.mode == modeReport || opts.mode == modeChangelog, "lz g report and changelog"},
+	} {
+		if f.set && !f.ok {
+			return opts, fmt.Errorf("%s: only with %s", f.flag, f.use)
+		}
+	}
+	if limitSet {
+		return opts, nil
+	}
+	configLimit := 0
+	if v := git.Config(".", "lz.historyLimit"); v != "" {
+		n, err := parseLimit(v)
+		if err != nil {
+			return opts, fmt.Errorf("lz.historyLimit: %w", err)
+		}
+		opts.limit, configLimit = n, n
+	}
+	if opts.mode == modeLog || opts.mode == modeReport {
+		opts.limit = max(configLimit, defaultTimelineLimit)
+	}
+	return opts, nil
+}
+
+// gitDate expands date shorthands git would misread: ages in the units
+// ui.RelativeTime prints ("3d", "2w", "1mo") and weekday names, which mean
+// the start of that day within the past week ("monday" on a Monday is
+// today). Anything else is passed through for git to parse.
+func gitDate(v string, now time.Time) string {
+	units := []struct{ suffix, word string }{
+		{"mo", "month"}, {"m", "minute"}, {"h", "hour"}, {"d", "day"}, {"w", "week"}, {"y", "year"},
+	}
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(v, u.suffix); ok {
+			if _, err := strconv.Atoi(n); err == nil {
+				if n != "1" {
+					u.word += "s"
+				}
+				return n + " " + u.word + " ago"
+			}
+		}
+	}
+
+	day := strings.ToLower(v)
+	if day == "today" {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Format(time.DateTime)
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if day == name || day == name[:3] {
+			back := (int(now.Weekday()) - int(wd) + 7) % 7
+			y, m, d := now.AddDate(0, 0, -back).Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Format(time.DateTime)
+		}
+	}
+	return v
+}
+
+// filterField maps a filter flag to its LogFilter field.
+func filterField(f *git.LogFilter, flag string) *string {
+	switch flag {
+	case "--author":
+		return &f.Author
+	case "--since":
+		return &f.Since
+	case "--until":
+		return &f.Until
+	case "--grep":
+		return &f.Grep
+	case "--path":
+		return &f.Path
+	default: // --range
+		return &f.Range
+	}
+}
+
+// describeFilter summarizes an active commit filter, e.g.
+// "author:ana · since:1 week ago".
+func describeFilter(f git.LogFilter) string {
+	var parts []string
+	for _, kv := range [][2]string{
+		{"author", f.Author}, {"since", f.Since}, {"until", f.Until},
+		{"grep", f.Grep}, {"path", f.Path}, {"range", f.Range},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+":"+kv[1])
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func parseLimit(v string) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("want a positive number, got %q", v)
+	}
+	return n, nil
+}
+
 // ── Shared data gathering ──
 
 type repoEntry struct {
-	repo    git.Repo
-	status  git.RepoStatus
-	commits []git.Commit
+	repo        git.Repo
+	status      git.RepoStatus
+	commits     []git.Commit
+	moreCommits bool // last page was full, so older commits may exist
+	loadingMore bool // a "load more" fetch is in flight
+	loading     bool // TUI: status being read; a placeholder unless cached
+	cached      bool // status came from the on-disk cache
+	stale       bool // cached status predates the repo's index or HEAD
+}
+
+func gatherEntries(opts gitOptions) ([]repoEntry, error) {
+	entries, err := discoverEntries()
+	if err != nil {
+		return nil, err
+	}
+	cache := git.LoadStatusCache()
+	var wg sync.WaitGroup
+	for i := range entries {
+		if opts.cached && entries[i].fromCache(cache) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entries[i].load(opts)
+		}()
+	}
+	wg.Wait()
+	sortEntries(entries)
+	saveStatusCache(cache, entries)
+	return entries, nil
 }
 
-func gatherEntries() ([]repoEntry, error) {
+// fromCache fills in the cached status, if any.
+func (e *repoEntry) fromCache(cache git.StatusCache) bool {
+	s, ok, stale := cache.Lookup(e.repo.Path)
+	if ok {
+		e.status, e.cached, e.stale = s, true, stale
+	}
+	return ok
+}
+
+// saveStatusCache records entries' statuses, read or cached, for the next
+// run. A failed write only costs the next startup its head start.
+func saveStatusCache(cache git.StatusCache, entries []repoEntry) {
+	for _, e := range entries {
+		if !e.cached {
+			cache.Put(e.repo.Path, e.status)
+		}
+	}
+	cache.Save()
+}
+
+// discoverEntries finds the repos under cwd without reading them.
+func discoverEntries() ([]repoEntry, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
@@ -60,20 +384,21 @@ func gatherEntries() ([]repoEntry, error) {
 	if err != nil {
 		return nil, err
 	}
-
 	entries := make([]repoEntry, len(repos))
-	var wg sync.WaitGroup
-	wg.Add(len(repos))
 	for i, r := range repos {
 		entries[i].repo = r
-		go func() {
-			defer wg.Done()
-			entries[i].status = git.GetStatus(r.Path)
-			entries[i].commits = git.RecentCommits(r.Path, defaultHistoryLimit)
-		}()
 	}
-	wg.Wait()
+	return entries, nil
+}
+
+func (e *repoEntry) load(opts gitOptions) {
+	e.status = git.GetStatus(e.repo.Path)
+	e.commits = fetchCommits(e.repo.Path, opts, 0)
+	e.moreCommits = len(e.commits) == opts.limit
+}
 
+// sortEntries puts the root (cwd) repo first, then dirty repos, then by name.
+func sortEntries(entries []repoEntry) {
 	slices.SortFunc(entries, func(a, b repoEntry) int {
 		// root (cwd) always first
 		if a.repo.Name == "root" {
@@ -91,14 +416,12 @@ func gatherEntries() ([]repoEntry, error) {
 		}
 		return cmp.Compare(a.repo.Name, b.repo.Name)
 	})
-
-	return entries, nil
 }
 
 // ── Non-interactive list mode (lz g -l) ──
 
-func runGitList() error {
-	entries, err := gatherEntries()
+func runGitList(opts gitOptions) error {
+	entries, err := gatherEntries(opts)
 	if err != nil {
 		return err
 	}
@@ -127,16 +450,22 @@ func runGitList() error {
 			parts = append(parts, padStyled(ui.Red.Render(c.behind), c.behind, cw[3]))
 		}
 		if cw[4] > 0 {
-			parts = append(parts, padStyled(c.stash, c.stash, cw[4]))
+			parts = append(parts, padStyled(styleBase(c), c.base, cw[4]))
 		}
 		if cw[5] > 0 {
+			parts = append(parts, padStyled(c.remotesStyled, c.remotes, cw[5]))
+		}
+		if cw[6] > 0 {
+			parts = append(parts, padStyled(c.stash, c.stash, cw[6]))
+		}
+		if cw[7] > 0 {
 			var tagStyled string
 			if c.tagAhead > 0 {
 				tagStyled = ui.Yellow.Render(c.tag)
 			} else {
 				tagStyled = ui.Green.Render(c.tag)
 			}
-			parts = append(parts, padStyled(tagStyled, c.tag, cw[5]))
+			parts = append(parts, padStyled(tagStyled, c.tag, cw[7]))
 		}
 		return strings.Join(parts, " ")
 	}
@@ -181,9 +510,12 @@ func runGitList() error {
 			fmt.Printf(" %s", extra)
 		}
 		fmt.Println()
+		if e.status.Err != nil {
+			fmt.Printf("   %s\n", ui.Red.Render(firstLine(e.status.Err.Error())))
+		}
 		if !e.status.IsClean {
 			for _, f := range e.status.Files {
-				for _, line := range renderFile(f) {
+				for _, line := range renderFile(f, nil) {
 					fmt.Printf("   %s\n", line)
 				}
 			}
@@ -195,8 +527,8 @@ func runGitList() error {
 
 // ── Non-interactive commits list (lz g -c) ──
 
-func runGitCommitList() error {
-	entries, err := gatherEntries()
+func runGitCommitList(opts gitOptions) error {
+	entries, err := gatherEntries(opts)
 	if err != nil {
 		return err
 	}
@@ -208,7 +540,11 @@ func runGitCommitList() error {
 	cols, cw, _ := computeRepoCols(entries)
 
 	// Build rows to compute widths.
-	rows := flattenCommitRows(entries)
+	rows := flattenCommitRows(entries, !opts.filter.IsZero())
+	if len(rows) == 0 {
+		fmt.Println("No matching commits.")
+		return nil
+	}
 	maxHashW := 0
 	maxRowAge := 0
 	maxTagW := 0
@@ -245,6 +581,10 @@ func runGitCommitList() error {
 	}
 
 	for i, e := range entries {
+		if !slices.ContainsFunc(rows, func(r row) bool { return r.entryIdx == i }) {
+			continue
+		}
+
 		// Repo header: name ··dots·· branch  age
 		c := cols[i]
 		left := fmt.Sprintf("── %s ", e.repo.Name)
@@ -307,8 +647,8 @@ func runGitCommitList() error {
 
 // ── Non-interactive stash list (lz g -s) ──
 
-func runGitStashList() error {
-	entries, err := gatherEntries()
+func runGitStashList(opts gitOptions) error {
+	entries, err := gatherEntries(opts)
 	if err != nil {
 		return err
 	}
@@ -405,9 +745,13 @@ type gitTab int
 const (
 	tabStatus  gitTab = iota
 	tabCommits
+	tabTimeline
 	tabStash
+	tabCount
 )
 
+var tabLabels = [tabCount]string{"Status", "Commits", "Timeline", "Stash"}
+
 type rowKind int
 
 const (
@@ -415,6 +759,8 @@ const (
 	rowFile
 	rowCommit
 	rowStash
+	rowDay   // timeline day separator
+	rowGraph // graph connector line between commits
 )
 
 type row struct {
@@ -431,58 +777,109 @@ type row struct {
 	stashIndex string
 	stashMsg   string
 	stashTime  time.Time
+	label      string // rowDay heading
+	graph      string // rowCommit, rowGraph: graph lanes
 }
 
+// isHeader reports whether the cursor skips this row.
+func (r row) isHeader() bool { return r.kind == rowRepo || r.kind == rowDay || r.kind == rowGraph }
+
 // repoCol holds precomputed column strings for a single repo header.
 type repoCol struct {
-	branch, age, ahead, behind, stash, tag string
-	tagAhead                                int // 0 = at tag, >0 = commits past tag
+	branch, age, ahead, behind, base, remotes, stash, tag string
+	remotesStyled                                         string
+	baseBehind                                            int // commits on the default branch not on HEAD
+	tagAhead                                              int // 0 = at tag, >0 = commits past tag
 }
 
 type gitModel struct {
+	opts     gitOptions
 	entries  []repoEntry
 	repoCols []repoCol // parallel to entries
-	colW     [6]int    // max width per column: branch, age, ahead, behind, stash, tag
+	colW     [8]int    // max width per column: branch, age, ahead, behind, base, remotes, stash, tag
 	maxNameW int       // max repo name width
 	rows     []row
 	cursor   int
 	tab      gitTab
 	viewing  bool
 	detail    ui.Scroll
-	diffLines []string
+	diffTitle string
+	diffRaw   string   // detail view source (git diff/show output)
+	diffLines []string // diffRaw rendered and wrapped for the current width and mode
+	diffFiles []diffFile // file sections, by line in diffLines
+	diffHunks []int      // hunk header lines in diffLines
+	diffFile  int        // 1-based file and hunk being viewed (0: before the first), stepped by ]/[ and n/p
+	diffHunk  int
+	split     bool     // side-by-side diff requested (see useSplit)
+	views     []gitView // screens stacked over the list; a diff may be open on top
 	primaryW  int // width of name-through-age section (dots fill the gap)
 	maxHashW    int // max commit hash width (for commits tab alignment)
 	maxIdxW     int // max stash index label width (for stash tab alignment)
 	maxRowAge   int // max age width across commit rows
 	maxStashAge int // max age width across stash rows
 	maxTagW     int // max tag width across commit rows
+	maxGraphW   int // max graph lane width across commit rows (0 = no graph)
+	timeline    timelineLayout
+	watch       *watcher // nil unless --watch
+	refreshing  bool     // an r reload is in flight
+	spinner     int      // spinner frame while refreshing
+	refreshErr  string   // why the last reload failed
+	query       string   // / filter over the current tab's rows
+	filtering   bool     // the query is being typed
+	searching   bool     // the commit search is being edited (s)
+	search      string   // commit search input, as key:value words
+	searchErr   string   // why the search input can't be applied
 	width     int
 	height    int
 }
 
-func initialGitModel() (gitModel, error) {
-	entries, err := gatherEntries()
+// initialGitModel lists the discovered repos as placeholders; Init reads
+// them in the background.
+func initialGitModel(opts gitOptions) (gitModel, error) {
+	entries, err := discoverEntries()
 	if err != nil {
 		return gitModel{}, err
 	}
-	m := gitModel{entries: entries, tab: tabStatus}
+	cache := git.LoadStatusCache()
+	all := true
+	for i := range entries {
+		entries[i].loading = true
+		all = entries[i].fromCache(cache) && all
+	}
+	if all {
+		sortEntries(entries)
+	}
+	m := gitModel{opts: opts, entries: entries, tab: tabStatus}
+	if !opts.filter.IsZero() {
+		m.tab = tabCommits
+	}
 	m.initRepoCols()
 	m.rebuildRows()
 	m.cursor = m.firstContentRow()
+	if opts.watch {
+		m.watch = newWatcher()
+	}
 	return m, nil
 }
 
 // computeRepoCols builds column strings and max widths for a set of entries.
-func computeRepoCols(entries []repoEntry) ([]repoCol, [6]int, int) {
+func computeRepoCols(entries []repoEntry) ([]repoCol, [8]int, int) {
 	cols := make([]repoCol, len(entries))
-	var cw [6]int
+	var cw [8]int
 	maxNameW := 0
 	for i, e := range entries {
 		s := e.status
 		c := &cols[i]
-		c.branch = s.Branch
+		if e.loading && !e.cached {
+			c.branch = "loading…"
+			continue
+		}
+		c.branch = branchLabel(s)
+		if e.stale {
+			c.branch += " (stale)"
+		}
 		c.age = ui.RelativeTime(s.Age)
-		if !s.HasUpstream {
+		if !s.HasUpstream && !s.Detached && !s.Unborn {
 			c.ahead = "∅"
 		} else if s.Ahead > 0 {
 			c.ahead = fmt.Sprintf("↑%d", s.Ahead)
@@ -490,6 +887,14 @@ func computeRepoCols(entries []repoEntry) ([]repoCol, [6]int, int) {
 		if s.Behind > 0 {
 			c.behind = fmt.Sprintf("↓%d", s.Behind)
 		}
+		if s.BaseAhead > 0 {
+			c.base = fmt.Sprintf("⇡%d", s.BaseAhead)
+		}
+		if s.BaseBehind > 0 {
+			c.base += fmt.Sprintf("⇣%d", s.BaseBehind)
+			c.baseBehind = s.BaseBehind
+		}
+		c.remotes, c.remotesStyled = formatRemotes(s.Remotes)
 		if len(s.Stashes) > 0 {
 			c.stash = fmt.Sprintf("≡%d", len(s.Stashes))
 		}
@@ -501,7 +906,7 @@ func computeRepoCols(entries []repoEntry) ([]repoCol, [6]int, int) {
 				c.tag = "@" + s.Tag
 			}
 		}
-		for j, v := range [6]string{c.branch, c.age, c.ahead, c.behind, c.stash, c.tag} {
+		for j, v := range [8]string{c.branch, c.age, c.ahead, c.behind, c.base, c.remotes, c.stash, c.tag} {
 			cw[j] = max(cw[j], runewidth.StringWidth(v))
 		}
 		maxNameW = max(maxNameW, runewidth.StringWidth(e.repo.Name))
@@ -509,6 +914,53 @@ func computeRepoCols(entries []repoEntry) ([]repoCol, [6]int, int) {
 	return cols, cw, maxNameW
 }
 
+// branchLabel names what HEAD points at: the branch, or the short hash and
+// nearest ref when detached. Unborn branches and shallow clones are flagged.
+func branchLabel(s git.RepoStatus) string {
+	label := s.Branch
+	if s.Detached {
+		label = s.Head
+		if s.HeadRef != "" {
+			label += " (" + s.HeadRef + ")"
+		}
+	}
+	switch {
+	case s.Unborn:
+		label += " (unborn)"
+	case s.Shallow:
+		label += " (shallow)"
+	}
+	return label
+}
+
+// styleBase colors the default-branch divergence column: yellow when the
+// default branch has moved on (the branch needs a rebase), faint otherwise.
+func styleBase(c repoCol) string {
+	if c.baseBehind > 0 {
+		return ui.Yellow.Render(c.base)
+	}
+	return ui.Faint.Render(c.base)
+}
+
+// formatRemotes renders per-remote divergence as "upstream↑1↓2", one entry
+// per tracked remote. A remote in sync with HEAD shows just its name.
+func formatRemotes(rs []git.RemoteStatus) (plain, styled string) {
+	var ps, ss []string
+	for _, r := range rs {
+		p, st := r.Name, ui.Faint.Render(r.Name)
+		if r.Ahead > 0 {
+			a := fmt.Sprintf("↑%d", r.Ahead)
+			p, st = p+a, st+ui.Green.Render(a)
+		}
+		if r.Behind > 0 {
+			b := fmt.Sprintf("↓%d", r.Behind)
+			p, st = p+b, st+ui.Red.Render(b)
+		}
+		ps, ss = append(ps, p), append(ss, st)
+	}
+	return strings.Join(ps, " "), strings.Join(ss, " ")
+}
+
 func (m *gitModel) initRepoCols() {
 	m.repoCols, m.colW, m.maxNameW = computeRepoCols(m.entries)
 }
@@ -518,16 +970,28 @@ func (m *gitModel) rebuildRows() {
 	case tabStatus:
 		m.rows = flattenRows(m.entries)
 	case tabCommits:
-		m.rows = flattenCommitRows(m.entries)
+		m.rows = flattenCommitRows(m.entries, !m.opts.filter.IsZero())
+	case tabTimeline:
+		m.rows = flattenTimelineRows(m.entries)
 	case tabStash:
 		m.rows = flattenStashRows(m.entries)
 	}
+	if m.query != "" {
+		m.rows = m.filterRows(m.rows)
+	}
+	if m.tab == tabTimeline {
+		m.timeline = computeTimelineLayout(m.rows, m.entries)
+	}
 	m.maxHashW = 0
 	m.maxIdxW = 0
 	m.maxRowAge = 0
 	m.maxStashAge = 0
 	m.maxTagW = 0
+	m.maxGraphW = 0
 	for _, r := range m.rows {
+		if r.kind == rowCommit {
+			m.maxGraphW = max(m.maxGraphW, runewidth.StringWidth(r.graph))
+		}
 		switch r.kind {
 		case rowCommit:
 			m.maxHashW = max(m.maxHashW, len(r.commitHash))
@@ -554,7 +1018,7 @@ func (m gitModel) computePrimaryWidth() int {
 		w := max(60, maxLeftW+3+1+m.colW[0]+1+ageW)
 		for _, r := range m.rows {
 			if r.kind == rowCommit {
-				rw := m.maxHashW + 2 + runewidth.StringWidth(r.commitMsg) + ageW + 6
+				rw := m.maxGraphW + 1 + m.maxHashW + 2 + runewidth.StringWidth(r.commitMsg) + ageW + 6
 				if r.commitTag != "" {
 					rw += runewidth.StringWidth("@" + r.commitTag)
 				}
@@ -562,6 +1026,8 @@ func (m gitModel) computePrimaryWidth() int {
 			}
 		}
 		return w
+	case tabTimeline:
+		return m.timeline.width + 2
 	case tabStash:
 		w := max(60, maxLeftW+3+1+m.colW[0])
 		for _, r := range m.rows {
@@ -590,18 +1056,20 @@ func (m gitModel) effectiveW() int {
 	return m.primaryW
 }
 
-func flattenCommitRows(entries []repoEntry) []row {
+// flattenCommitRows lists each repo's commits under its header. With
+// hideEmpty (a commit filter is active), repos without commits are skipped.
+func flattenCommitRows(entries []repoEntry, hideEmpty bool) []row {
 	var rows []row
 	for i, e := range entries {
+		if hideEmpty && len(e.commits) == 0 {
+			continue
+		}
 		rows = append(rows, row{
 			kind:     rowRepo,
 			entryIdx: i,
 			repoName: e.repo.Name,
 		})
-		for j, c := range e.commits {
-			if j >= defaultHistoryLimit {
-				break
-			}
+		for _, c := range e.commits {
 			rows = append(rows, row{
 				kind:       rowCommit,
 				entryIdx:   i,
@@ -610,7 +1078,11 @@ func flattenCommitRows(entries []repoEntry) []row {
 				commitMsg:  c.Subject,
 				commitTime: c.Time,
 				commitTag:  c.Tag,
+				graph:      c.Graph,
 			})
+			for _, g := range c.GraphAfter {
+				rows = append(rows, row{kind: rowGraph, entryIdx: i, repoName: e.repo.Name, graph: g})
+			}
 		}
 	}
 	return rows
@@ -677,7 +1149,7 @@ func (m gitModel) moveCursor(from, delta int) int {
 		return 0
 	}
 	pos := (from + delta + n) % n
-	for i := 0; i < n && m.rows[pos].kind == rowRepo; i++ {
+	for i := 0; i < n && m.rows[pos].isHeader(); i++ {
 		pos = (pos + delta + n) % n
 	}
 	return pos
@@ -685,14 +1157,285 @@ func (m gitModel) moveCursor(from, delta int) int {
 
 func (m gitModel) firstContentRow() int {
 	for i, r := range m.rows {
-		if r.kind != rowRepo {
+		if !r.isHeader() {
 			return i
 		}
 	}
 	return 0
 }
 
-func (m gitModel) Init() tea.Cmd { return nil }
+// rowID identifies a row across rebuilds, which shift indexes.
+type rowID struct {
+	repo string // repo path
+	item string // file, commit, stash or day within the repo
+}
+
+func (m gitModel) rowID(i int) rowID {
+	if i >= len(m.rows) {
+		return rowID{}
+	}
+	r := m.rows[i]
+	return rowID{m.entries[r.entryIdx].repo.Path, fmt.Sprint(r.kind, "\x00", r.filePath, r.commitHash, r.stashIndex, r.label)}
+}
+
+// restoreCursor moves the cursor back to the row id names. If that row is
+// gone it lands on the first row of the same repo, else near the old index.
+func (m *gitModel) restoreCursor(id rowID, old int) {
+	sameRepo := -1
+	for i := range m.rows {
+		if m.rows[i].isHeader() {
+			continue
+		}
+		switch got := m.rowID(i); {
+		case got == id:
+			m.cursor = i
+			return
+		case got.repo == id.repo && sameRepo < 0:
+			sameRepo = i
+		}
+	}
+	if sameRepo >= 0 {
+		m.cursor = sameRepo
+		return
+	}
+	m.cursor = min(old, max(len(m.rows)-1, 0))
+	if m.cursor < len(m.rows) && m.rows[m.cursor].isHeader() {
+		m.cursor = m.moveCursor(m.cursor, -1)
+	}
+}
+
+func (m gitModel) Init() tea.Cmd {
+	cmds := []tea.Cmd{spin()}
+	for _, e := range m.entries {
+		cmds = append(cmds, m.refreshRepo(e.repo.Path))
+	}
+	if m.watch != nil {
+		cmds = append(cmds, poll(m.repoPaths()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadingCount is the number of repos still being read.
+func (m gitModel) loadingCount() int {
+	n := 0
+	for _, e := range m.entries {
+		if e.loading {
+			n++
+		}
+	}
+	return n
+}
+
+// commitMode is the listing state commits were fetched under: the g/a
+// toggles and the search. Results fetched under a mode since changed are
+// dropped.
+type commitMode struct {
+	graph, branches bool
+	filter          git.LogFilter
+}
+
+func (o gitOptions) commitMode() commitMode {
+	return commitMode{graph: o.graph, branches: o.branches, filter: o.filter}
+}
+
+// commitPageMsg delivers a page of older commits for one repo.
+type commitPageMsg struct {
+	path    string
+	commits []git.Commit
+	skip    int  // commits loaded when the page was requested
+	reload  bool // commits replace the list rather than extend it (graphs)
+	mode    commitMode
+}
+
+// loadMoreCommits fetches the next page of history in the background when
+// the cursor reaches a repo's last loaded commit in the Commits tab.
+func (m *gitModel) loadMoreCommits() tea.Cmd {
+	if m.tab != tabCommits || m.cursor >= len(m.rows) {
+		return nil
+	}
+	r := m.rows[m.cursor]
+	if r.kind != rowCommit || !m.isLastCommitRow(m.cursor) {
+		return nil
+	}
+	e := &m.entries[r.entryIdx]
+	if !e.moreCommits || e.loadingMore {
+		return nil
+	}
+	e.loadingMore = true
+	path, opts, skip := e.repo.Path, m.opts, len(e.commits)
+	return func() tea.Msg {
+		return commitPageMsg{path: path, commits: fetchCommits(path, opts, skip), skip: skip, reload: opts.graph, mode: opts.commitMode()}
+	}
+}
+
+// fetchCommits loads the page of history after the first skip commits.
+// Graphs reload from the top instead, since lanes can't resume mid-history.
+func fetchCommits(path string, opts gitOptions, skip int) []git.Commit {
+	if opts.graph {
+		return git.CommitGraph(path, opts.filter, opts.branches, skip+opts.limit)
+	}
+	return git.CommitPage(path, opts.filter, opts.branches, skip, opts.limit)
+}
+
+// commitReloadMsg replaces one repo's commits after a g/a toggle or a new
+// search.
+type commitReloadMsg struct {
+	path    string
+	commits []git.Commit
+	more    bool
+	mode    commitMode
+}
+
+// reloadCommits refetches every repo's first page in the background, after
+// the listing options change. The old commits stay listed until each
+// repo's new page arrives.
+func (m gitModel) reloadCommits() tea.Cmd {
+	opts := m.opts
+	var cmds []tea.Cmd
+	for _, e := range m.entries {
+		path := e.repo.Path
+		cmds = append(cmds, func() tea.Msg {
+			commits := fetchCommits(path, opts, 0)
+			return commitReloadMsg{path: path, commits: commits, more: len(commits) == opts.limit, mode: opts.commitMode()}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// applyCommitReload swaps in a repo's reloaded commits. A page still in
+// flight was fetched for the old list and is dropped when it arrives.
+func (m *gitModel) applyCommitReload(msg commitReloadMsg) {
+	if msg.mode != m.opts.commitMode() {
+		return
+	}
+	id := m.rowID(m.cursor)
+	for i := range m.entries {
+		if e := &m.entries[i]; e.repo.Path == msg.path {
+			e.commits, e.moreCommits = msg.commits, msg.more
+		}
+	}
+	m.rebuildRows()
+	m.restoreCursor(id, m.cursor)
+}
+
+// repoRefreshMsg replaces one repo's status and commits.
+type repoRefreshMsg struct {
+	path    string
+	status  git.RepoStatus
+	commits []git.Commit
+	more    bool
+	mode    commitMode
+}
+
+// refreshRepo reloads one repo's status and as many commits as are loaded.
+func (m gitModel) refreshRepo(path string) tea.Cmd {
+	opts := m.opts
+	for _, e := range m.entries {
+		if e.repo.Path == path {
+			opts.limit = max(opts.limit, len(e.commits))
+		}
+	}
+	return func() tea.Msg {
+		commits := fetchCommits(path, opts, 0)
+		return repoRefreshMsg{path: path, status: git.GetStatus(path), commits: commits, more: len(commits) == opts.limit, mode: opts.commitMode()}
+	}
+}
+
+// applyRefresh swaps in a repo's new state, keeping the cursor on the same
+// row. Commits stay put while a page is loading, so the page isn't appended
+// to a list it wasn't fetched for. Once the last placeholder is filled in,
+// repos are sorted as gatherEntries would and the status cache is saved.
+func (m *gitModel) applyRefresh(msg repoRefreshMsg) tea.Cmd {
+	id := m.rowID(m.cursor)
+	onHeader := m.cursor >= len(m.rows) || m.rows[m.cursor].isHeader()
+	settled := false
+	for i := range m.entries {
+		e := &m.entries[i]
+		if e.repo.Path != msg.path {
+			continue
+		}
+		if e.loading {
+			e.loading = false
+			settled = m.loadingCount() == 0
+		}
+		e.status, e.cached, e.stale = msg.status, false, false
+		// A g/a toggle or search since the read has its own reload coming.
+		if !e.loadingMore && msg.mode == m.opts.commitMode() {
+			e.commits, e.moreCommits = msg.commits, msg.more
+		}
+	}
+	var save tea.Cmd
+	if settled {
+		sortEntries(m.entries)
+		entries := slices.Clone(m.entries)
+		save = func() tea.Msg {
+			saveStatusCache(git.LoadStatusCache(), entries)
+			return nil
+		}
+	}
+	m.initRepoCols()
+	m.rebuildRows()
+	if onHeader {
+		// Nothing was selectable yet: take the first row that is.
+		m.cursor = m.firstContentRow()
+	} else {
+		m.restoreCursor(id, m.cursor)
+	}
+	return save
+}
+
+// gitReloadMsg carries a fresh scan of every repo.
+type gitReloadMsg struct {
+	entries []repoEntry
+	err     error
+	mode    commitMode
+}
+
+// refresh rescans all repos in the background. The old entries stay on
+// screen until the new ones arrive.
+func (m *gitModel) refresh() tea.Cmd {
+	if m.refreshing {
+		return nil
+	}
+	m.refreshing, m.refreshErr = true, ""
+	return tea.Batch(spin(), m.rescan())
+}
+
+func (m gitModel) rescan() tea.Cmd {
+	opts := m.opts
+	return func() tea.Msg {
+		entries, err := gatherEntries(opts)
+		return gitReloadMsg{entries: entries, err: err, mode: opts.commitMode()}
+	}
+}
+
+func (m *gitModel) applyReload(msg gitReloadMsg) tea.Cmd {
+	if msg.err == nil && msg.mode != m.opts.commitMode() {
+		// g/a or the search changed since the scan started: its commits
+		// are for the old listing.
+		return m.rescan()
+	}
+	m.refreshing = false
+	if msg.err != nil {
+		m.refreshErr = firstLine(msg.err.Error())
+		return nil
+	}
+	id := m.rowID(m.cursor)
+	m.entries = msg.entries
+	m.initRepoCols()
+	m.rebuildRows()
+	m.restoreCursor(id, m.cursor)
+	return nil
+}
+
+// isLastCommitRow reports whether row i is the last commit row of its repo.
+func (m gitModel) isLastCommitRow(i int) bool {
+	next := i + 1
+	for next < len(m.rows) && m.rows[next].kind == rowGraph {
+		next++
+	}
+	return next >= len(m.rows) || m.rows[next].kind != rowCommit || m.rows[next].entryIdx != m.rows[i].entryIdx
+}
 
 func (m gitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -700,57 +1443,201 @@ func (m gitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.detail.Height = max(msg.Height-4, 1)
+		if m.viewing {
+			m.layoutDiff()
+		}
+	case commitPageMsg:
+		id := m.rowID(m.cursor)
+		for i := range m.entries {
+			e := &m.entries[i]
+			if e.repo.Path != msg.path {
+				continue
+			}
+			// A reload since the request replaced the list the page follows.
+			if msg.mode != m.opts.commitMode() || len(e.commits) != msg.skip {
+				e.loadingMore = false
+				continue
+			}
+			if msg.reload {
+				e.moreCommits = len(msg.commits) == len(e.commits)+m.opts.limit
+				e.commits = msg.commits
+			} else {
+				e.commits = append(e.commits, msg.commits...)
+				e.moreCommits = len(msg.commits) == m.opts.limit
+			}
+			e.loadingMore = false
+		}
+		m.rebuildRows()
+		m.restoreCursor(id, m.cursor)
+	case releaseMsg:
+		var cmds []tea.Cmd
+		for _, v := range m.views {
+			if t, ok := v.(*tagsView); ok && t.repo == msg.repo {
+				cmds = append(cmds, t.finish(msg))
+			}
+		}
+		for i := range m.entries {
+			if e := &m.entries[i]; e.repo.Path == msg.repo && msg.created() {
+				e.status.Tag, e.status.TagAhead = msg.tag, 0
+			}
+		}
+		m.initRepoCols()
+		return m, tea.Batch(cmds...)
+	case tagCountMsg:
+		for _, v := range m.views {
+			if t, ok := v.(*tagsView); ok && t.repo == msg.repo {
+				t.setCounts(msg.counts)
+			}
+		}
+	case watchMsg:
+		return m, m.handleWatch(msg)
+	case repoRefreshMsg:
+		return m, m.applyRefresh(msg)
+	case commitReloadMsg:
+		m.applyCommitReload(msg)
+	case gitReloadMsg:
+		return m, m.applyReload(msg)
+	case spinMsg:
+		if m.refreshing || m.loadingCount() > 0 {
+			m.spinner++
+			return m, spin()
+		}
 	case tea.KeyMsg:
 		if m.viewing {
 			return m.updateDetail(msg)
 		}
+		if len(m.views) > 0 {
+			return m.updateView(msg)
+		}
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
 		return m.updateList(msg)
 	}
 	return m, nil
 }
 
 func (m gitModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc", "ctrl+c":
+	switch key := msg.String(); key {
+	case "esc":
+		if m.query == "" {
+			return m, tea.Quit
+		}
+		m.clearFilter()
+	case "q", "ctrl+c":
 		return m, tea.Quit
+	case "/":
+		m.filtering = true
+	case "s":
+		if m.tab == tabCommits || m.tab == tabTimeline {
+			m.startSearch()
+		}
 	case "up", "k":
 		m.cursor = m.moveCursor(m.cursor, -1)
+		return m, m.loadMoreCommits()
 	case "down", "j":
 		m.cursor = m.moveCursor(m.cursor, 1)
+		return m, m.loadMoreCommits()
 	case "tab":
-		m.tab = (m.tab + 1) % 3
+		m.tab = (m.tab + 1) % tabCount
 		m.rebuildRows()
 		m.cursor = m.firstContentRow()
 	case "shift+tab":
-		m.tab = (m.tab + 2) % 3
+		m.tab = (m.tab + tabCount - 1) % tabCount
+		m.rebuildRows()
+		m.cursor = m.firstContentRow()
+	case "g", "a":
+		// --range names its own revisions, so a has nothing to add.
+		if m.tab != tabCommits || key == "a" && m.opts.filter.Range != "" {
+			break
+		}
+		if key == "g" {
+			m.opts.graph = !m.opts.graph
+		} else {
+			m.opts.branches = !m.opts.branches
+		}
 		m.rebuildRows()
 		m.cursor = m.firstContentRow()
+		return m, m.reloadCommits()
+	case "r":
+		return m, m.refresh()
+	case "t":
+		if m.cursor < len(m.rows) {
+			return m, m.openTags(m.entries[m.rows[m.cursor].entryIdx])
+		}
+	case "H":
+		if m.cursor < len(m.rows) && m.rows[m.cursor].kind == rowFile {
+			m.openHistory(m.rows[m.cursor])
+		}
+	case "b":
+		if m.cursor < len(m.rows) && m.rows[m.cursor].kind == rowFile {
+			r := m.rows[m.cursor]
+			path := r.filePath
+			if _, to, ok := strings.Cut(path, " -> "); ok {
+				path = to
+			}
+			m.openBlame(m.entries[r.entryIdx].repo.Path, r.repoName, "", path, 0)
+		}
 	case "enter", "right", "l":
 		if m.cursor >= len(m.rows) {
 			break
 		}
 		r := m.rows[m.cursor]
 		e := m.entries[r.entryIdx]
-		var raw string
 		switch r.kind {
 		case rowFile:
-			raw = git.Diff(e.repo.Path, r.filePath, r.fileXY)
+			m.openDiff(r.repoName+" — "+r.filePath, git.Diff(e.repo.Path, r.filePath, r.fileXY))
 		case rowCommit:
-			raw = git.ShowCommit(e.repo.Path, r.commitHash)
+			m.openCommit(e.repo.Path, r.repoName, r.commitHash, r.commitMsg)
 		case rowStash:
-			raw = git.ShowStash(e.repo.Path, r.stashIndex)
-		default:
-			break
-		}
-		if r.kind != rowRepo {
-			m.diffLines = colorDiff(raw)
-			m.viewing = true
-			m.detail = ui.Scroll{Height: max(m.height-4, 1), Total: len(m.diffLines)}
+			m.openDiff(r.repoName+" — stash@{"+r.stashIndex+"} "+r.stashMsg, git.ShowStash(e.repo.Path, r.stashIndex))
 		}
 	}
 	return m, nil
 }
 
+// openDiff shows raw diff output in the detail view.
+func (m *gitModel) openDiff(title, raw string) {
+	m.diffTitle = title
+	m.diffRaw = raw
+	m.viewing = true
+	m.detail = ui.Scroll{Height: max(m.height-4, 1)}
+	m.layoutDiff()
+}
+
+// gitView is a screen pushed over the repo list: a commit, a file's history
+// or blame. Back keys pop it; other keys go to update.
+type gitView interface {
+	update(m *gitModel, key string) tea.Cmd
+	view(m gitModel) string
+}
+
+// modalView is a gitView that sometimes takes the back keys for itself,
+// e.g. to cancel a prompt.
+type modalView interface {
+	modal() bool
+}
+
+func (m gitModel) updateView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	top := m.views[len(m.views)-1]
+	key := msg.String()
+	if v, ok := top.(modalView); ok && v.modal() && key != "ctrl+c" {
+		return m, top.update(&m, key)
+	}
+	switch key {
+	case "q", "esc", "backspace", "left", "h":
+		m.views = m.views[:len(m.views)-1]
+	case "ctrl+c":
+		return m, tea.Quit
+	default:
+		return m, top.update(&m, key)
+	}
+	return m, nil
+}
+
 func (m gitModel) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 	switch key {
@@ -759,16 +1646,115 @@ func (m gitModel) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "ctrl+c":
 		return m, tea.Quit
+	case "s":
+		m.split = !m.split
+		m.layoutDiff()
+	case "n", "p":
+		// Step the index rather than search from the offset: a hunk that
+		// starts on the last screen can't be scrolled to the top.
+		i := m.diffHunk + 1
+		if key == "p" {
+			i = m.diffHunk - 1
+		}
+		if i >= 1 && i <= len(m.diffHunks) {
+			line := m.diffHunks[i-1]
+			m.diffHunk, m.diffFile = i, anchorsAbove(fileLines(m.diffFiles), line)
+			m.scrollDiff(line)
+		}
+	case "]", "[":
+		i := m.diffFile + 1
+		if key == "[" {
+			i = m.diffFile - 1
+		}
+		if i >= 1 && i <= len(m.diffFiles) {
+			line := m.diffFiles[i-1].line
+			m.diffFile, m.diffHunk = i, anchorsAbove(m.diffHunks, line)
+			m.scrollDiff(line)
+		}
 	default:
+		offset := m.detail.Offset
 		m.detail.HandleKey(key)
+		if m.detail.Offset != offset {
+			m.syncDiffPosition()
+		}
 	}
 	return m, nil
 }
 
+// scrollDiff scrolls the detail view to line, or as near as it goes.
+func (m *gitModel) scrollDiff(line int) {
+	m.detail.Offset = line
+	m.detail.Clamp()
+}
+
+// syncDiffPosition points diffFile and diffHunk at the section at the top
+// of the detail view, after scrolling by line.
+func (m *gitModel) syncDiffPosition() {
+	m.diffFile = anchorsAbove(fileLines(m.diffFiles), m.detail.Offset)
+	m.diffHunk = anchorsAbove(m.diffHunks, m.detail.Offset)
+}
+
+// useSplit reports whether the detail view renders side by side: requested
+// with s, and only when the terminal is wide enough.
+func (m gitModel) useSplit() bool {
+	return m.split && m.width >= minSplitWidth
+}
+
+// layoutDiff renders diffRaw for the current mode and width, and maps its
+// files and hunks to rendered lines for navigation.
+func (m *gitModel) layoutDiff() {
+	var at []int
+	if m.useSplit() {
+		m.diffLines, at = splitDiff(m.diffRaw, m.width)
+	} else {
+		m.diffLines = nil
+		for _, l := range colorDiff(m.diffRaw) {
+			at = append(at, len(m.diffLines))
+			m.diffLines = append(m.diffLines, ui.WrapLine(l, m.width)...)
+		}
+	}
+
+	files, hunks := diffOutline(strings.Split(strings.TrimRight(m.diffRaw, "\n"), "\n"))
+	m.diffFiles, m.diffHunks = nil, nil
+	for _, f := range files {
+		m.diffFiles = append(m.diffFiles, diffFile{name: f.name, line: at[f.line]})
+	}
+	for _, h := range hunks {
+		m.diffHunks = append(m.diffHunks, at[h])
+	}
+	m.detail.Total = len(m.diffLines)
+	m.detail.Clamp()
+	m.syncDiffPosition()
+}
+
+// diffPosition describes the file and hunk being viewed, e.g.
+// "main.go · hunk 3/12". The file is only named in multi-file diffs.
+func (m gitModel) diffPosition() string {
+	var parts []string
+	if len(m.diffFiles) > 1 && m.diffFile > 0 {
+		parts = append(parts, fmt.Sprintf("%s (%d/%d)", m.diffFiles[m.diffFile-1].name, m.diffFile, len(m.diffFiles)))
+	}
+	if len(m.diffHunks) > 0 {
+		parts = append(parts, fmt.Sprintf("hunk %d/%d", max(m.diffHunk, 1), len(m.diffHunks)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func fileLines(files []diffFile) []int {
+	lines := make([]int, len(files))
+	for i, f := range files {
+		lines[i] = f.line
+	}
+	return lines
+}
+
 func (m gitModel) View() string {
 	if m.viewing {
 		return m.viewDetail()
 	}
+	if len(m.views) > 0 {
+		return m.views[len(m.views)-1].view(m)
+	}
 	return m.viewList()
 }
 
@@ -779,7 +1765,24 @@ func (m gitModel) viewList() string {
 
 	var b strings.Builder
 
-	b.WriteString(ui.RenderTabBar([]string{"Status", "Commits", "Stash"}, int(m.tab)))
+	b.WriteString(ui.RenderTabBar(tabLabels[:], int(m.tab)))
+	if (m.tab == tabCommits || m.tab == tabTimeline) && !m.opts.filter.IsZero() {
+		b.WriteString("  " + ui.Faint.Render(describeFilter(m.opts.filter)))
+	}
+	if m.tab == tabCommits && m.opts.branches {
+		b.WriteString("  " + ui.Faint.Render("all branches"))
+	}
+	if m.query != "" && !m.filtering {
+		b.WriteString("  " + ui.Faint.Render("/"+m.query))
+	}
+	switch n := m.loadingCount(); {
+	case n > 0:
+		b.WriteString("  " + ui.Spinner(m.spinner) + ui.Faint.Render(fmt.Sprintf(" loading %d/%d", len(m.entries)-n, len(m.entries))))
+	case m.refreshing:
+		b.WriteString("  " + ui.Spinner(m.spinner) + ui.Faint.Render(" refreshing"))
+	case m.refreshErr != "":
+		b.WriteString("  " + ui.Red.Render("refresh failed: "+m.refreshErr))
+	}
 	b.WriteString("\n\n")
 
 	var lines []string
@@ -798,15 +1801,37 @@ func (m gitModel) viewList() string {
 				}
 			}
 			lines = append(lines, m.renderRepoRow(r))
+		case rowDay:
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, ui.Faint.Render("  ── ")+ui.Bold.Render(r.label))
 		case rowFile:
 			lines = append(lines, m.renderFileRow(r, isCursor))
+		case rowGraph:
+			lines = append(lines, "    "+colorGraph(r.graph))
 		case rowCommit:
+			if m.tab == tabTimeline {
+				prefix := "    "
+				if isCursor {
+					prefix = "  ▸ "
+				}
+				lines = append(lines, m.timeline.render(r, prefix, m.effectiveW()-2, isCursor, m.mark))
+				break
+			}
 			lines = append(lines, m.renderCommitRow(r, isCursor))
+			if m.entries[r.entryIdx].loadingMore && m.isLastCommitRow(i) {
+				lines = append(lines, ui.Faint.Render("    ⋯ loading"))
+			}
 		case rowStash:
 			lines = append(lines, m.renderStashRow(r, isCursor))
 		}
 	}
 
+	if len(m.rows) == 0 && m.query != "" {
+		lines = append(lines, ui.Faint.Render("  No matches."))
+	}
+
 	listH := m.height - 4 // tab bar + blank + help + padding
 	if listH > 0 && len(lines) > listH {
 		start := ui.KeepCursorVisible(cursorLine, len(lines), listH)
@@ -821,13 +1846,32 @@ func (m gitModel) viewList() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString(ui.RenderHelp("↑/↓ navigate", "enter detail", "tab switch", "q quit"))
+	help := []string{"↑/↓ navigate", "enter detail", "t tags"}
+	switch m.tab {
+	case tabStatus:
+		help = append(help, "H file history", "b blame")
+	case tabCommits:
+		help = append(help, "s search", "g graph")
+		if m.opts.filter.Range == "" {
+			help = append(help, "a all branches")
+		}
+	case tabTimeline:
+		help = append(help, "s search")
+	}
+	switch {
+	case m.filtering:
+		b.WriteString(m.filterBar())
+		return b.String()
+	case m.searching:
+		b.WriteString(m.searchBar())
+		return b.String()
+	}
+	b.WriteString(ui.RenderHelp(append(help, "/ filter", "r refresh", "tab switch", "q quit")...))
 	return b.String()
 }
 
 func (m gitModel) renderRepoRow(r row) string {
 	e := m.entries[r.entryIdx]
-	s := e.status
 	c := m.repoCols[r.entryIdx]
 
 	// Commits tab: name ··dots·· branch  age (no extras)
@@ -836,13 +1880,8 @@ func (m gitModel) renderRepoRow(r row) string {
 		left := "── " + e.repo.Name + " "
 		branchW := runewidth.StringWidth(c.branch)
 		dotsW := max(m.effectiveW()-runewidth.StringWidth(left)-branchW-ageW-2, 3)
-		var branchStyled string
-		if !s.IsClean {
-			branchStyled = ui.Cyan.Render(c.branch)
-		} else {
-			branchStyled = c.branch
-		}
-		return ui.Faint.Render("  ── ") + ui.Bold.Render(e.repo.Name) + " " +
+		branchStyled := styleBranch(e, c.branch)
+		return ui.Faint.Render("  ── ") + m.mark(e.repo.Name, ui.Bold) + " " +
 			ui.Faint.Render(strings.Repeat("·", dotsW)) + " " + branchStyled + " " + ui.Faint.Render(c.age)
 	}
 
@@ -851,13 +1890,8 @@ func (m gitModel) renderRepoRow(r row) string {
 		left := "── " + e.repo.Name + " "
 		branchW := runewidth.StringWidth(c.branch)
 		dotsW := max(m.effectiveW()-runewidth.StringWidth(left)-branchW-1, 3)
-		var branchStyled string
-		if !s.IsClean {
-			branchStyled = ui.Cyan.Render(c.branch)
-		} else {
-			branchStyled = c.branch
-		}
-		return ui.Faint.Render("  ── ") + ui.Bold.Render(e.repo.Name) + " " +
+		branchStyled := styleBranch(e, c.branch)
+		return ui.Faint.Render("  ── ") + m.mark(e.repo.Name, ui.Bold) + " " +
 			ui.Faint.Render(strings.Repeat("·", dotsW)) + " " + branchStyled
 	}
 
@@ -873,13 +1907,7 @@ func (m gitModel) renderRepoRow(r row) string {
 	dotsW := max(m.primaryW-runewidth.StringWidth(left)-runewidth.StringWidth(c.branch)-m.colW[1]-2, 3)
 	dots := strings.Repeat("·", dotsW)
 
-	// Styled branch
-	var branchStyled string
-	if !s.IsClean {
-		branchStyled = ui.Cyan.Render(c.branch)
-	} else {
-		branchStyled = c.branch
-	}
+	branchStyled := styleBranch(e, c.branch)
 	age := padS(ui.Faint.Render(c.age), c.age, m.colW[1])
 
 	// Styled extras
@@ -887,7 +1915,7 @@ func (m gitModel) renderRepoRow(r row) string {
 		val   string
 		style func(string) string
 	}
-	extraStyles := [4]colStyle{
+	extraStyles := [6]colStyle{
 		{c.ahead, func(v string) string {
 			if v == "∅" {
 				return ui.Faint.Render(v)
@@ -895,6 +1923,8 @@ func (m gitModel) renderRepoRow(r row) string {
 			return ui.Green.Render(v)
 		}},
 		{c.behind, func(v string) string { return ui.Red.Render(v) }},
+		{c.base, func(string) string { return styleBase(c) }},
+		{c.remotes, func(string) string { return c.remotesStyled }},
 		{c.stash, func(v string) string { return v }},
 		{c.tag, func(v string) string {
 			if c.tagAhead > 0 {
@@ -904,7 +1934,7 @@ func (m gitModel) renderRepoRow(r row) string {
 		}},
 	}
 	var extraStyled string
-	for i := 2; i < 6; i++ {
+	for i := 2; i < 8; i++ {
 		if m.colW[i] > 0 {
 			es := extraStyles[i-2]
 			if es.val != "" {
@@ -915,15 +1945,27 @@ func (m gitModel) renderRepoRow(r row) string {
 		}
 	}
 
-	return ui.Faint.Render("  ── ") + ui.Bold.Render(e.repo.Name) + " " +
+	return ui.Faint.Render("  ── ") + m.mark(e.repo.Name, ui.Bold) + " " +
 		ui.Faint.Render(dots) + " " + branchStyled + " " + age + extraStyled
 }
 
+// styleBranch colors a repo header's branch: cyan when dirty, faint for a
+// placeholder or a cached status being revalidated.
+func styleBranch(e repoEntry, label string) string {
+	switch {
+	case e.loading:
+		return ui.Faint.Render(label)
+	case !e.status.IsClean:
+		return ui.Cyan.Render(label)
+	}
+	return label
+}
+
 func (m gitModel) renderFileRow(r row, cursor bool) string {
 	e := m.entries[r.entryIdx]
 	f := e.status.Files[r.fileIdx]
 
-	fileLines := renderFile(f)
+	fileLines := renderFile(f, m.mark)
 	line := fileLines[0]
 	// For renames, join both lines
 	if len(fileLines) > 1 {
@@ -940,35 +1982,46 @@ func (m gitModel) renderFileRow(r row, cursor bool) string {
 }
 
 func (m gitModel) renderCommitRow(r row, cursor bool) string {
-	hash := r.commitHash
-	hashPad := strings.Repeat(" ", max(m.maxHashW-len(hash), 0))
-	age := ui.RelativeTime(r.commitTime)
+	ageW := max(m.colW[1], m.maxRowAge)
+	return commitLine(r.graph, r.commitHash, r.commitMsg, r.commitTag, ui.RelativeTime(r.commitTime), m.maxGraphW, m.maxHashW, ageW, m.effectiveW(), cursor, m.markPlain)
+}
+
+// commitLine renders "    graph hash  subject···@tag  age" in width+2
+// columns, leaving room for graphs, hashes and ages up to graphW, hashW and
+// ageW wide. mark, if set, styles the subject of non-cursor rows.
+func commitLine(graph, hash, msg, tag, age string, graphW, hashW, ageW, width int, cursor bool, mark func(string) string) string {
+	if graphW > 0 {
+		graph += strings.Repeat(" ", graphW-runewidth.StringWidth(graph)) + " "
+		graphW++
+	}
+	hashPad := strings.Repeat(" ", max(hashW-len(hash), 0))
 
 	var tagPlain string
-	if r.commitTag != "" {
-		tagPlain = "@" + r.commitTag
+	if tag != "" {
+		tagPlain = "@" + tag
 	}
 
-	// All rows share total width 2+effectiveW.
-	// "    hash  subject···tag  age" → middleW = effectiveW - maxHashW - ageW - 6
-	ageW := max(m.colW[1], m.maxRowAge)
-	middleW := max(m.effectiveW()-m.maxHashW-ageW-6, 20)
+	// "    graph hash  subject···tag  age" → middleW = width - graphW - hashW - ageW - 6
+	middleW := max(width-graphW-hashW-ageW-6, 20)
 	subjectW := middleW
 	if tagPlain != "" {
 		subjectW = max(middleW-runewidth.StringWidth(tagPlain), 10)
 	}
-	subject := ui.Truncate(r.commitMsg, subjectW)
+	subject := ui.Truncate(msg, subjectW)
 	dotsW := max(subjectW-runewidth.StringWidth(subject), 0)
 	dots := strings.Repeat("·", dotsW)
 
 	if cursor {
-		return ui.Cursor.Render("  ▸ " + hash + hashPad + "  " + subject + dots + tagPlain + "  " + age)
+		return ui.Cursor.Render("  ▸ " + graph + hash + hashPad + "  " + subject + dots + tagPlain + "  " + age)
 	}
 	tagStyled := ""
 	if tagPlain != "" {
 		tagStyled = ui.Green.Render(tagPlain)
 	}
-	return "    " + ui.Yellow.Render(hash) + hashPad + "  " + subject + ui.Faint.Render(dots) + tagStyled + "  " + ui.Faint.Render(age)
+	if mark != nil {
+		subject = mark(subject)
+	}
+	return "    " + colorGraph(graph) + ui.Yellow.Render(hash) + hashPad + "  " + subject + ui.Faint.Render(dots) + tagStyled + "  " + ui.Faint.Render(age)
 }
 
 func (m gitModel) renderStashRow(r row, cursor bool) string {
@@ -985,88 +2038,80 @@ func (m gitModel) renderStashRow(r row, cursor bool) string {
 	if cursor {
 		return ui.Cursor.Render("  ▸ " + idx + idxPad + "  " + subject + dots + "  " + age)
 	}
-	return "    " + ui.Yellow.Render(idx) + idxPad + "  " + subject + ui.Faint.Render(dots) + "  " + ui.Faint.Render(age)
+	return "    " + ui.Yellow.Render(idx) + idxPad + "  " + m.markPlain(subject) + ui.Faint.Render(dots) + "  " + ui.Faint.Render(age)
 }
 
 func (m gitModel) viewDetail() string {
 	var b strings.Builder
 
-	r := m.rows[m.cursor]
-	var title string
-	switch r.kind {
-	case rowFile:
-		title = r.repoName + " — " + r.filePath
-	case rowCommit:
-		title = r.repoName + " — " + r.commitHash + " " + r.commitMsg
-	case rowStash:
-		title = r.repoName + " — stash@{" + r.stashIndex + "} " + r.stashMsg
-	default:
-		title = r.repoName
+	b.WriteString(ui.DetailTitle.Render("← " + m.diffTitle))
+	if pos := m.diffPosition(); pos != "" {
+		b.WriteString(ui.Faint.Render("  " + pos))
 	}
-	b.WriteString(ui.DetailTitle.Render("← " + title))
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", m.width))
 	b.WriteString("\n")
 
-	var wrapped []string
-	for _, l := range m.diffLines {
-		wrapped = append(wrapped, ui.WrapLine(l, m.width)...)
-	}
-	for _, l := range m.detail.Visible(wrapped) {
+	for _, l := range m.detail.Visible(m.diffLines) {
 		b.WriteString(l)
 		b.WriteString("\n")
 	}
 
-	b.WriteString(ui.RenderHelp("↑/↓ scroll", "g/G top/bottom", "← back"+m.detail.Percent()))
-	return b.String()
-}
-
-// ── Diff coloring ──
-
-func colorDiff(raw string) []string {
-	if raw == "" {
-		return []string{ui.Faint.Render("  (no diff)")}
+	mode := "s side-by-side"
+	switch {
+	case m.useSplit():
+		mode = "s unified"
+	case m.split:
+		mode = fmt.Sprintf("s unified (side-by-side needs %d cols)", minSplitWidth)
 	}
-	src := strings.Split(strings.TrimRight(raw, "\n"), "\n")
-	out := make([]string, 0, len(src))
-	for _, line := range src {
-		out = append(out, colorDiffLine(line))
+	nav := "n/p hunk"
+	if len(m.diffFiles) > 1 {
+		nav += " · ]/[ file"
 	}
-	return out
+	b.WriteString(ui.RenderHelp("↑/↓ scroll", "g/G top/bottom", nav, mode, "← back"+m.detail.Percent()))
+	return b.String()
 }
 
-func colorDiffLine(line string) string {
-	switch {
-	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
-		return ui.Bold.Render(line)
-	case strings.HasPrefix(line, "@@"):
-		return ui.Cyan.Render(line)
-	case strings.HasPrefix(line, "+"):
-		return ui.Green.Render(line)
-	case strings.HasPrefix(line, "-"):
-		return ui.Red.Render(line)
-	case strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
-		return ui.Faint.Render(line)
-	default:
-		return line
+// graphLanes color git --graph lanes by column, as git log --color does.
+var graphLanes = []lipgloss.Style{ui.Red, ui.Green, ui.Yellow, ui.Blue, ui.Magenta, ui.Cyan}
+
+// colorGraph styles a --graph prefix: each lane's lines in its own color,
+// commit markers bold.
+func colorGraph(graph string) string {
+	var b strings.Builder
+	for i, ch := range []rune(graph) {
+		switch ch {
+		case ' ':
+			b.WriteRune(ch)
+		case '*':
+			b.WriteString(ui.Bold.Render("*"))
+		default:
+			b.WriteString(graphLanes[i/2%len(graphLanes)].Render(string(ch)))
+		}
 	}
+	return b.String()
 }
 
 // ── Shared file rendering ──
 
-func renderFile(f git.FileStatus) []string {
+// renderFile draws a file's status line, or two for renames. mark, if set,
+// styles the (new) path.
+func renderFile(f git.FileStatus, mark func(string, lipgloss.Style) string) []string {
 	ch, style := fileSign(f.XY)
 	render := style.Render
+	if mark == nil {
+		mark = func(s string, style lipgloss.Style) string { return style.Render(s) }
+	}
 
 	if strings.Contains(f.File, " -> ") {
 		parts := strings.SplitN(f.File, " -> ", 2)
 		return []string{
 			ui.Faint.Render(string(ch) + " " + parts[0]),
-			render("→ " + parts[1]),
+			render("→ ") + mark(parts[1], style),
 		}
 	}
 
-	return []string{render(string(ch) + " " + f.File)}
+	return []string{render(string(ch)+" ") + mark(f.File, style)}
 }
 
 func fileSign(xy string) (rune, lipgloss.Style) {
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"aliz/lz/internal/git"
+	"aliz/lz/internal/ui"
+)
+
+// ── Blame ──
+
+// blameView annotates a file with the commit that last changed each line,
+// as of rev (or the working tree). p re-blames at the selected line's
+// parent commit, pushing another blameView.
+type blameView struct {
+	repo     string // repo path
+	repoName string
+	rev      string // "" for the working tree
+	path     string
+	lines    []git.BlameLine
+	cursor   int
+}
+
+// blameBands alternate between runs of lines from the same commit.
+var blameBands = [2]lipgloss.Style{ui.Yellow, ui.Blue}
+
+func (m *gitModel) openBlame(repo, repoName, rev, path string, cursor int) {
+	b := &blameView{repo: repo, repoName: repoName, rev: rev, path: path, lines: git.Blame(repo, rev, path)}
+	b.cursor = max(min(cursor, len(b.lines)-1), 0)
+	m.views = append(m.views, b)
+}
+
+func (b *blameView) update(m *gitModel, key string) tea.Cmd {
+	page := max(m.height-5, 1)
+	switch key {
+	case "up", "k":
+		b.cursor = max(b.cursor-1, 0)
+	case "down", "j":
+		b.cursor = max(min(b.cursor+1, len(b.lines)-1), 0)
+	case "pgup", "ctrl+u":
+		b.cursor = max(b.cursor-page, 0)
+	case "pgdown", "ctrl+d":
+		b.cursor = max(min(b.cursor+page, len(b.lines)-1), 0)
+	case "g":
+		b.cursor = 0
+	case "G":
+		b.cursor = max(len(b.lines)-1, 0)
+	case "enter", "right", "l":
+		if b.cursor < len(b.lines) && !b.lines[b.cursor].Uncommitted() {
+			l := b.lines[b.cursor]
+			m.openCommit(b.repo, b.repoName, l.Hash, l.Summary)
+		}
+	case "p":
+		if b.cursor < len(b.lines) && b.lines[b.cursor].PrevHash != "" {
+			l := b.lines[b.cursor]
+			m.openBlame(b.repo, b.repoName, l.PrevHash, l.PrevPath, l.OrigLine-1)
+		}
+	}
+	return nil
+}
+
+func (b *blameView) view(m gitModel) string {
+	var sb strings.Builder
+
+	at := "working tree"
+	if b.rev != "" {
+		at = shortHash(b.rev)
+	}
+	sb.WriteString(ui.DetailTitle.Render("← " + b.repoName + " — " + b.path))
+	sb.WriteString(ui.Faint.Render("  blame @ " + at))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", m.width))
+	sb.WriteString("\n")
+
+	if len(b.lines) == 0 {
+		sb.WriteString(ui.Faint.Render("  Nothing to blame (untracked, deleted or binary file)."))
+		sb.WriteString("\n")
+	}
+
+	const authorW = 14
+	ageW := 0
+	for _, l := range b.lines {
+		ageW = max(ageW, len(ui.RelativeTime(l.Time)))
+	}
+	numW := len(fmt.Sprint(len(b.lines)))
+	annotW := 2 + 7 + 2 + authorW + 2 + ageW
+	codeW := max(m.width-annotW-numW-3, 1)
+	lexer := lexerFor(b.path)
+
+	height := max(m.height-4, 1)
+	start := ui.KeepCursorVisible(b.cursor, len(b.lines), height)
+	end := min(start+height, len(b.lines))
+
+	// Band parity depends on every run above the viewport.
+	band := 0
+	for i := 1; i <= start; i++ {
+		if b.lines[i].Hash != b.lines[i-1].Hash {
+			band ^= 1
+		}
+	}
+	for i := start; i < end; i++ {
+		l := b.lines[i]
+		runStart := i == 0 || l.Hash != b.lines[i-1].Hash
+		if runStart && i > start {
+			band ^= 1
+		}
+
+		hash, author := shortHash(l.Hash), l.Author
+		if l.Uncommitted() {
+			hash, author = "·······", "uncommitted"
+		}
+		author = ui.Truncate(author, authorW)
+		author += strings.Repeat(" ", authorW-runewidth.StringWidth(author))
+		annot := fmt.Sprintf("%s  %s  %*s", hash, author, ageW, ui.RelativeTime(l.Time))
+		num := fmt.Sprintf("%*d", numW, i+1)
+		switch {
+		case i == b.cursor:
+			sb.WriteString(ui.Cursor.Render("▸ " + annot + " " + num + " │"))
+		case runStart:
+			sb.WriteString(blameBands[band].Render("▌ "+annot) + ui.Faint.Render(" "+num+" │"))
+		default:
+			sb.WriteString(blameBands[band].Render("▌") + strings.Repeat(" ", annotW-1) + ui.Faint.Render(" "+num+" │"))
+		}
+		code := ui.Truncate(strings.ReplaceAll(l.Text, "\t", "    "), codeW)
+		sb.WriteString(" " + highlightLine("", code, lexer, lineContext, nil))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(ui.RenderHelp("↑/↓ select", "enter commit", "p blame parent", "← back"))
+	return sb.String()
+}
+
+// shortHash abbreviates a full hash for display.
+func shortHash(h string) string {
+	return h[:min(len(h), 7)]
+}
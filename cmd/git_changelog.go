@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"aliz/lz/internal/git"
+)
+
+// ── Changelog (lz g changelog) ──
+
+func runGitChangelog(opts gitOptions) error {
+	entries, err := gatherEntries(opts)
+	if err != nil {
+		return err
+	}
+	if opts.repo != "" {
+		var match []repoEntry
+		for _, e := range entries {
+			if e.repo.Name == opts.repo || filepath.Base(e.repo.Path) == opts.repo {
+				match = append(match, e)
+			}
+		}
+		if len(match) == 0 {
+			return fmt.Errorf("no repo named %q", opts.repo)
+		}
+		entries = match
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Changelog — %s\n", time.Now().Format("Mon 2 Jan 2006"))
+	listed := 0
+entries:
+	for _, e := range entries {
+		from, to := opts.from, cmp.Or(opts.to, "HEAD")
+		// Tags rarely exist in every repo: only a named repo must have them.
+		for _, ref := range []string{opts.from, opts.to} {
+			if ref == "" {
+				continue
+			}
+			if err := git.VerifyCommit(e.repo.Path, ref); err != nil {
+				if opts.repo != "" {
+					return fmt.Errorf("%s: %w", e.repo.Name, err)
+				}
+				fmt.Fprintf(os.Stderr, "skipped %s: %v\n", e.repo.Name, err)
+				continue entries
+			}
+		}
+		switch {
+		case from != "":
+		case opts.to != "":
+			from = git.TagBefore(e.repo.Path, to)
+		default:
+			from = e.status.Tag
+		}
+		cl := buildChangelog(git.CommitsBetween(e.repo.Path, from, to))
+		if cl.total == 0 {
+			continue
+		}
+		listed++
+		noun := "commits"
+		if cl.total == 1 {
+			noun = "commit"
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n_%s → %s · %d %s_\n", e.repo.Name, cmp.Or(from, "start"), to, cl.total, noun)
+		b.WriteString(cl.markdown("###"))
+	}
+	if listed == 0 {
+		b.WriteString("\nNo changes.\n")
+	}
+
+	if opts.output == "" {
+		fmt.Print(b.String())
+		return nil
+	}
+	if err := os.WriteFile(opts.output, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s\n", opts.output)
+	return nil
+}
+
+// conventionalRe matches "type(scope)!: description".
+var conventionalRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// changeEntry is one changelog line.
+type changeEntry struct {
+	scope, desc, hash string
+}
+
+// changelog groups commits by conventional-commit type.
+type changelog struct {
+	breaking, features, fixes, perf []changeEntry
+	other                           int // commits of other types, or not conventional
+	total                           int
+}
+
+func buildChangelog(commits []git.Commit) changelog {
+	var cl changelog
+	for _, c := range commits {
+		cl.total++
+		m := conventionalRe.FindStringSubmatch(c.Subject)
+		if m == nil {
+			cl.other++
+			continue
+		}
+		typ, e := strings.ToLower(m[1]), changeEntry{scope: m[2], desc: m[4], hash: c.Hash}
+		if m[3] == "!" || strings.Contains(c.Body, "BREAKING CHANGE:") || strings.Contains(c.Body, "BREAKING-CHANGE:") {
+			cl.breaking = append(cl.breaking, e)
+			continue
+		}
+		switch typ {
+		case "feat":
+			cl.features = append(cl.features, e)
+		case "fix":
+			cl.fixes = append(cl.fixes, e)
+		case "perf":
+			cl.perf = append(cl.perf, e)
+		default:
+			cl.other++
+		}
+	}
+	return cl
+}
+
+// bump suggests the semver part to increase: 2 = major for breaking
+// changes, 1 = minor for features, else 0 = patch.
+func (cl changelog) bump() int {
+	switch {
+	case len(cl.breaking) > 0:
+		return 2
+	case len(cl.features) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// markdown renders the sections under headings of the given level, e.g.
+// "###".
+func (cl changelog) markdown(heading string) string {
+	var b strings.Builder
+	for _, s := range []struct {
+		title   string
+		entries []changeEntry
+	}{
+		{"Breaking changes", cl.breaking},
+		{"Features", cl.features},
+		{"Fixes", cl.fixes},
+		{"Performance", cl.perf},
+	} {
+		if len(s.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s %s\n\n", heading, s.title)
+		for _, e := range s.entries {
+			scope := ""
+			if e.scope != "" {
+				scope = "**" + e.scope + ":** "
+			}
+			fmt.Fprintf(&b, "- %s%s (`%s`)\n", scope, e.desc, e.hash)
+		}
+	}
+	if cl.other > 0 {
+		noun := "commits"
+		if cl.other == 1 {
+			noun = "commit"
+		}
+		fmt.Fprintf(&b, "\n_%d other %s not listed._\n", cl.other, noun)
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"aliz/lz/internal/git"
+	"aliz/lz/internal/ui"
+)
+
+// ── Commit detail (header + file list) ──
+
+// commitView is an open commit: its header and changed files, one of which
+// may be shown in the diff view on top.
+type commitView struct {
+	repo     string // repo path
+	repoName string
+	title    string // "repo — hash subject"
+	detail   git.CommitDetail
+	cursor   int // selected file
+}
+
+func (m *gitModel) openCommit(repo, repoName, hash, subject string) {
+	m.views = append(m.views, &commitView{
+		repo:     repo,
+		repoName: repoName,
+		title:    repoName + " — " + shortHash(hash) + " " + subject,
+		detail:   git.GetCommitDetail(repo, hash),
+	})
+}
+
+func (c *commitView) update(m *gitModel, key string) tea.Cmd {
+	switch key {
+	case "up", "k":
+		c.cursor = max(c.cursor-1, 0)
+	case "down", "j":
+		c.cursor = max(min(c.cursor+1, len(c.detail.Files)-1), 0)
+	case "g":
+		c.cursor = 0
+	case "G":
+		c.cursor = max(len(c.detail.Files)-1, 0)
+	case "enter", "right", "l":
+		if c.cursor < len(c.detail.Files) {
+			f := c.detail.Files[c.cursor]
+			m.openDiff(c.title+" — "+f.Path, git.ShowCommitFile(c.repo, c.detail.Hash, f.Path, f.OldPath))
+		}
+	case "a":
+		m.openDiff(c.title, git.ShowCommit(c.repo, c.detail.Hash))
+	case "b":
+		if c.cursor < len(c.detail.Files) {
+			f := c.detail.Files[c.cursor]
+			rev := c.detail.Hash
+			if f.Status == 'D' {
+				rev += "^" // blame what was deleted
+			}
+			m.openBlame(c.repo, c.repoName, rev, f.Path, 0)
+		}
+	}
+	return nil
+}
+
+// commitHeader renders the commit metadata and message, wrapped to width.
+func commitHeader(d git.CommitDetail, width int) []string {
+	label := func(name, value string) string {
+		return ui.Faint.Render(fmt.Sprintf("%-11s", name)) + value
+	}
+	when := func(t time.Time) string {
+		return ui.Faint.Render("  " + t.Format("Mon 2 Jan 2006 15:04 -0700") + " · " + ui.RelativeTime(t))
+	}
+
+	title := ui.Yellow.Render("commit " + d.Hash)
+	if d.IsMerge() {
+		title += "  " + ui.Magenta.Render("merge")
+	}
+	lines := []string{
+		title,
+		label("Author:", d.Author+when(d.AuthorTime)),
+		label("Committer:", d.Committer+when(d.CommitTime)),
+	}
+	if len(d.Parents) > 0 {
+		lines = append(lines, label("Parents:", ui.Yellow.Render(strings.Join(d.Parents, " "))))
+	}
+	if len(d.Refs) > 0 {
+		lines = append(lines, label("Refs:", ui.Cyan.Render(strings.Join(d.Refs, ", "))))
+	}
+	lines = append(lines, label("Signature:", signatureLabel(d.Signature, d.Signer)), "")
+	for i, l := range strings.Split(d.Message, "\n") {
+		if i == 0 {
+			l = ui.Bold.Render(l)
+		}
+		for _, w := range ui.WrapLine(l, max(width-4, 1)) {
+			lines = append(lines, "    "+w)
+		}
+	}
+	lines = append(lines, "")
+
+	added, deleted := 0, 0
+	for _, f := range d.Files {
+		added += f.Added
+		deleted += f.Deleted
+	}
+	noun := "files"
+	if len(d.Files) == 1 {
+		noun = "file"
+	}
+	summary := fmt.Sprintf("%d %s changed, %s %s", len(d.Files), noun,
+		ui.Green.Render(fmt.Sprintf("+%d", added)), ui.Red.Render(fmt.Sprintf("-%d", deleted)))
+	if d.IsMerge() {
+		summary += ui.Faint.Render(" (against first parent)")
+	}
+	return append(lines, summary)
+}
+
+// signatureLabel describes a %G? verification code.
+func signatureLabel(code byte, signer string) string {
+	by := ""
+	if signer != "" {
+		by = " from " + signer
+	}
+	switch code {
+	case 'G':
+		return ui.Green.Render("✓ good" + by)
+	case 'U':
+		return ui.Green.Render("✓ good"+by) + ui.Faint.Render(" (unknown validity)")
+	case 'X':
+		return ui.Yellow.Render("✓ good" + by + ", signature expired")
+	case 'Y':
+		return ui.Yellow.Render("✓ good" + by + ", key expired")
+	case 'R':
+		return ui.Red.Render("✓ good" + by + ", key revoked")
+	case 'B':
+		return ui.Red.Render("✗ bad" + by)
+	case 'E':
+		return ui.Yellow.Render("? cannot check (missing key)")
+	default:
+		return ui.Faint.Render("unsigned")
+	}
+}
+
+// renderFileChanges lays out the file list: status, path and line counts,
+// with the counts right-aligned in a shared column.
+func renderFileChanges(files []git.FileChange, cursor, width int) []string {
+	paths := make([]string, len(files))
+	addW, delW := 0, 0
+	for i, f := range files {
+		paths[i] = f.Path
+		if f.OldPath != "" {
+			paths[i] = f.OldPath + " → " + f.Path
+		}
+		addW = max(addW, len(fmt.Sprint(f.Added))+1)
+		delW = max(delW, len(fmt.Sprint(f.Deleted))+1)
+	}
+	statW := addW + 1 + delW
+	pathW := max(width-4-3-2-statW, 1) // "  ▸ M  path  +1 -2"
+
+	lines := make([]string, len(files))
+	for i, f := range files {
+		path := ui.Truncate(paths[i], pathW)
+		pad := strings.Repeat(" ", max(pathW-lipgloss.Width(path), 0))
+		name := "    " + changeStyle(f.Status).Render(string(f.Status)) + "  " + path
+		if i == cursor {
+			name = ui.Cursor.Render("  ▸ " + string(f.Status) + "  " + path)
+		}
+
+		var stat string
+		if f.Binary {
+			stat = ui.Faint.Render(fmt.Sprintf("%*s", statW, "bin"))
+		} else {
+			stat = ui.Green.Render(fmt.Sprintf("%*s", addW, fmt.Sprintf("+%d", f.Added))) + " " +
+				ui.Red.Render(fmt.Sprintf("%*s", delW, fmt.Sprintf("-%d", f.Deleted)))
+		}
+		lines[i] = name + pad + "  " + stat
+	}
+	return lines
+}
+
+func changeStyle(status byte) lipgloss.Style {
+	switch status {
+	case 'A':
+		return ui.Green
+	case 'D':
+		return ui.Red
+	case 'R', 'C':
+		return ui.Cyan
+	default:
+		return ui.Yellow
+	}
+}
+
+func (c *commitView) view(m gitModel) string {
+	var b strings.Builder
+
+	b.WriteString(ui.DetailTitle.Render("← " + c.title))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.width))
+	b.WriteString("\n")
+
+	header := commitHeader(c.detail, m.width)
+	lines := append(header, renderFileChanges(c.detail.Files, c.cursor, m.width)...)
+	height := max(m.height-4, 1)
+	start := ui.KeepCursorVisible(len(header)+c.cursor, len(lines), height)
+	end := min(start+height, len(lines))
+	for _, l := range lines[start:end] {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(ui.RenderHelp("↑/↓ select", "enter file diff", "a full diff", "b blame", "← back"))
+	return b.String()
+}
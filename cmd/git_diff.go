@@ -0,0 +1,553 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"aliz/lz/internal/ui"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ── Diff coloring ──
+
+// diffTheme is the syntax highlighting palette for diff views: a chroma
+// style plus background tints for added and removed lines, and stronger
+// tints for the words that changed within them.
+type diffTheme struct {
+	style            *chroma.Style
+	addBg, delBg     lipgloss.Color
+	addEmph, delEmph lipgloss.Color
+}
+
+// theme is nil when highlighting is off; diffs then only color +/- lines.
+var theme *diffTheme
+
+// initDiffTheme turns on syntax highlighting for 256-color and truecolor
+// terminals. Must be called before alt screen — the OSC query for the
+// background color times out inside it.
+func initDiffTheme() {
+	switch lipgloss.ColorProfile() {
+	case termenv.TrueColor, termenv.ANSI256:
+	default:
+		return
+	}
+	if termenv.HasDarkBackground() {
+		theme = &diffTheme{style: styles.Get("monokai"),
+			addBg: "#1e3a24", delBg: "#3f1e22", addEmph: "#2e6b3c", delEmph: "#7a2e38"}
+	} else {
+		theme = &diffTheme{style: styles.Get("github"),
+			addBg: "#dafbe1", delBg: "#ffe4e6", addEmph: "#aceebb", delEmph: "#ffc1c0"}
+	}
+}
+
+// hunkLine classifies a line inside a hunk.
+type hunkLine int
+
+const (
+	lineContext hunkLine = iota
+	lineAdd
+	lineDel
+)
+
+// style is the foreground for the line's sign column, and for the whole line
+// when it is not syntax-highlighted.
+func (k hunkLine) style() lipgloss.Style {
+	switch k {
+	case lineAdd:
+		return ui.Green
+	case lineDel:
+		return ui.Red
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// bg is the line's tint; emph selects the stronger changed-word tint.
+func (k hunkLine) bg(emph bool) lipgloss.TerminalColor {
+	switch {
+	case theme == nil || k == lineContext:
+		return lipgloss.NoColor{}
+	case k == lineAdd && emph:
+		return theme.addEmph
+	case k == lineAdd:
+		return theme.addBg
+	case emph:
+		return theme.delEmph
+	default:
+		return theme.delBg
+	}
+}
+
+func colorDiff(raw string) []string {
+	if raw == "" {
+		return []string{ui.Faint.Render("  (no diff)")}
+	}
+	src := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+	emph := wordDiff(src)
+	out := make([]string, 0, len(src))
+	var lexer chroma.Lexer
+	signW := 0 // width of the +/- column inside a hunk; 0 outside hunks
+	for i, line := range src {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			lexer, signW = lexerFor(diffPath(line)), 0
+		case strings.HasPrefix(line, "@@"):
+			// "@@" for plain diffs, "@@@" for a two-parent combined diff, …
+			signW = len(line) - len(strings.TrimLeft(line, "@")) - 1
+		}
+		out = append(out, colorDiffLine(line, lexer, signW, emph[i]))
+	}
+	return out
+}
+
+// colorDiffLine colors one line of diff output. Inside a hunk (signW > 0)
+// code is syntax-highlighted and the byte ranges in emph (changed words,
+// relative to the code after the sign column) are emphasized.
+func colorDiffLine(line string, lexer chroma.Lexer, signW int, emph [][2]int) string {
+	if signW > 0 && len(line) >= signW && !strings.HasPrefix(line, "@@") {
+		sign, code := line[:signW], line[signW:]
+		switch {
+		case strings.HasPrefix(line, `\`): // "\ No newline at end of file"
+			return ui.Faint.Render(line)
+		case strings.Contains(sign, "+"):
+			return highlightLine(sign, code, lexer, lineAdd, emph)
+		case strings.Contains(sign, "-"):
+			return highlightLine(sign, code, lexer, lineDel, emph)
+		default:
+			return highlightLine(sign, code, lexer, lineContext, nil)
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return ui.Bold.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return ui.Cyan.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return ui.Green.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return ui.Red.Render(line)
+	case strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+		return ui.Faint.Render(line)
+	default:
+		return line
+	}
+}
+
+// highlightLine renders one hunk line: the sign column in the kind's color
+// and the code syntax-highlighted on the kind's tint, with the emph byte
+// ranges of code on the stronger tint. Lines are lexed one at a time, so
+// constructs spanning lines (block comments, raw strings) may be colored as
+// code. Without a theme or a lexer the whole line takes the kind's color,
+// and emphasized ranges are shown in reverse video.
+func highlightLine(sign, code string, lexer chroma.Lexer, kind hunkLine, emph [][2]int) string {
+	var toks []chroma.Token
+	if theme != nil && lexer != nil {
+		if it, err := lexer.Tokenise(nil, code); err == nil {
+			toks = it.Tokens()
+		}
+	}
+	if toks == nil {
+		// One plain token: only emphasis splits the line.
+		toks = []chroma.Token{{Type: chroma.None, Value: code}}
+	}
+
+	var b strings.Builder
+	b.WriteString(kind.style().Background(kind.bg(false)).Render(sign))
+	pos := 0
+	for _, tok := range toks {
+		text := strings.ReplaceAll(tok.Value, "\n", "") // lexers append a newline
+		for text != "" {
+			// Split the token where emphasis starts or stops.
+			in, n := emphSpan(emph, pos, len(text))
+			st := kind.style()
+			if tok.Type != chroma.None {
+				st = tokenStyle(tok.Type)
+			}
+			st = st.Background(kind.bg(in))
+			if in && theme == nil {
+				st = st.Reverse(true)
+			}
+			b.WriteString(st.Render(text[:n]))
+			text, pos = text[n:], pos+n
+		}
+	}
+	return b.String()
+}
+
+// emphSpan reports whether byte pos lies in one of the ranges, and how many
+// of the next n bytes share that state.
+func emphSpan(ranges [][2]int, pos, n int) (bool, int) {
+	for _, r := range ranges {
+		switch {
+		case pos >= r[0] && pos < r[1]:
+			return true, min(n, r[1]-pos)
+		case r[0] > pos:
+			return false, min(n, r[0]-pos)
+		}
+	}
+	return false, n
+}
+
+// tokenStyle maps a chroma token type to a lipgloss style via the theme.
+func tokenStyle(t chroma.TokenType) lipgloss.Style {
+	e := theme.style.Get(t)
+	st := lipgloss.NewStyle()
+	if e.Colour.IsSet() {
+		st = st.Foreground(lipgloss.Color(e.Colour.String()))
+	}
+	if e.Bold == chroma.Yes {
+		st = st.Bold(true)
+	}
+	if e.Italic == chroma.Yes {
+		st = st.Italic(true)
+	}
+	return st
+}
+
+// lexerFor picks a chroma lexer by file name, or nil if the language is
+// unknown.
+func lexerFor(path string) chroma.Lexer {
+	if path == "" {
+		return nil
+	}
+	l := lexers.Match(filepath.Base(path))
+	if l == nil {
+		return nil
+	}
+	return chroma.Coalesce(l)
+}
+
+// diffPath extracts the (new) file path from a "diff --git a/x b/x" or
+// "diff --cc x" header line.
+func diffPath(header string) string {
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[i+3:]
+	}
+	fields := strings.Fields(header)
+	return fields[len(fields)-1]
+}
+
+// ── Side-by-side diff ──
+
+// minSplitWidth is the narrowest terminal that gets a side-by-side diff;
+// below it the unified view is used.
+const minSplitWidth = 120
+
+// splitDiff renders raw diff output as two aligned columns, old on the left
+// and new on the right, each with line numbers. Removed and added runs
+// within a hunk are paired line by line; long lines wrap within their
+// column. Headers, commit messages and combined (merge) hunks span the full
+// width as in the unified view. at maps each raw line to the index of the
+// first output line showing it.
+func splitDiff(raw string, width int) (out []string, at []int) {
+	if raw == "" {
+		return colorDiff(raw), []int{0}
+	}
+	sideW := (width - 3) / 2 // "left │ right"
+	var lexer chroma.Lexer
+	signW, numW := 0, 0
+	oldNo, newNo := 0, 0
+	src := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+	emph := wordDiff(src)
+	at = make([]int, len(src))
+	var dels, adds []int // indexes into src of the pending -/+ run
+
+	emit := func(left, right []string) {
+		for i := range max(len(left), len(right)) {
+			l, r := strings.Repeat(" ", sideW), ""
+			if i < len(left) {
+				l = left[i]
+			}
+			if i < len(right) {
+				r = right[i]
+			}
+			out = append(out, l+ui.Faint.Render(" │ ")+r)
+		}
+	}
+	flush := func() {
+		for i := range max(len(dels), len(adds)) {
+			var left, right []string
+			if i < len(dels) {
+				at[dels[i]] = len(out)
+				oldNo++
+				left = splitCell(oldNo, numW, src[dels[i]][1:], lexer, lineDel, emph[dels[i]], sideW)
+			}
+			if i < len(adds) {
+				at[adds[i]] = len(out)
+				newNo++
+				right = splitCell(newNo, numW, src[adds[i]][1:], lexer, lineAdd, emph[adds[i]], sideW)
+			}
+			emit(left, right)
+		}
+		dels, adds = nil, nil
+	}
+
+	for i, line := range src {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			flush()
+			lexer, signW = lexerFor(diffPath(line)), 0
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			signW = len(line) - len(strings.TrimLeft(line, "@")) - 1
+			var oldEnd, newEnd int
+			oldNo, newNo, oldEnd, newEnd = parseHunkHeader(line)
+			numW = len(strconv.Itoa(max(oldEnd, newEnd)))
+			oldNo, newNo = oldNo-1, newNo-1 // incremented before use
+		}
+
+		if signW == 1 && !strings.HasPrefix(line, "@@") && !strings.HasPrefix(line, `\`) {
+			sign, code := " ", ""
+			if line != "" {
+				sign, code = line[:1], line[1:]
+			}
+			switch sign {
+			case "-":
+				dels = append(dels, i)
+				continue
+			case "+":
+				adds = append(adds, i)
+				continue
+			case " ":
+				flush()
+				at[i] = len(out)
+				oldNo++
+				newNo++
+				emit(splitCell(oldNo, numW, code, lexer, lineContext, nil, sideW),
+					splitCell(newNo, numW, code, lexer, lineContext, nil, sideW))
+				continue
+			}
+		}
+		flush()
+		at[i] = len(out)
+		out = append(out, ui.WrapLine(colorDiffLine(line, lexer, signW, emph[i]), width)...)
+	}
+	flush()
+	return out, at
+}
+
+// splitCell renders one side of a side-by-side row: a line number gutter and
+// the highlighted code wrapped to width, every line padded to exactly width
+// cells (with the line's tint, if any).
+func splitCell(no, numW int, code string, lexer chroma.Lexer, kind hunkLine, emph [][2]int, width int) []string {
+	gutter := fmt.Sprintf("%*d ", numW, no)
+	codeW := max(width-len(gutter), 1)
+	wrapped := ui.WrapLine(highlightLine("", code, lexer, kind, emph), codeW)
+	pad := lipgloss.NewStyle().Background(kind.bg(false))
+	for i, l := range wrapped {
+		g := ui.Faint.Render(gutter)
+		if i > 0 {
+			g = strings.Repeat(" ", len(gutter))
+		}
+		wrapped[i] = g + l + pad.Render(strings.Repeat(" ", max(codeW-lipgloss.Width(l), 0)))
+	}
+	return wrapped
+}
+
+// parseHunkHeader reads "@@ -a,b +c,d @@" into the first line numbers and
+// the (exclusive) end of each side. Combined-diff headers ("@@@ -a,b -c,d
+// +e,f @@@") report the first old range.
+func parseHunkHeader(line string) (oldStart, newStart, oldEnd, newEnd int) {
+	span := func(r string) (int, int) {
+		start, n, ok := strings.Cut(r, ",")
+		s, _ := strconv.Atoi(start)
+		count := 1
+		if ok {
+			count, _ = strconv.Atoi(n)
+		}
+		return s, s + count
+	}
+	for i, f := range strings.Fields(line) {
+		switch {
+		case i > 0 && strings.HasPrefix(f, "@"):
+			return oldStart, newStart, oldEnd, newEnd // end of ranges
+		case strings.HasPrefix(f, "-") && oldStart == 0:
+			oldStart, oldEnd = span(f[1:])
+		case strings.HasPrefix(f, "+"):
+			newStart, newEnd = span(f[1:])
+		}
+	}
+	return oldStart, newStart, oldEnd, newEnd
+}
+
+// ── Word-level highlighting ──
+
+// wordDiff finds the changed words in paired -/+ lines. Within a hunk, a run
+// of removed lines followed by a run of added lines of the same length is
+// paired line by line (like diff-highlight). The result maps a line's index
+// in src to byte ranges of its code (after the sign column).
+func wordDiff(src []string) map[int][][2]int {
+	out := map[int][][2]int{}
+	var dels, adds []int
+	flush := func() {
+		if len(dels) == len(adds) {
+			for k := range dels {
+				a, b := src[dels[k]][1:], src[adds[k]][1:]
+				if ra, rb := changedRanges(a, b); ra != nil || rb != nil {
+					out[dels[k]], out[adds[k]] = ra, rb
+				}
+			}
+		}
+		dels, adds = nil, nil
+	}
+
+	signW := 0
+	for i, line := range src {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			signW = 0
+		case strings.HasPrefix(line, "@@"):
+			signW = len(line) - len(strings.TrimLeft(line, "@")) - 1
+		case signW == 1 && strings.HasPrefix(line, "-"):
+			if len(adds) > 0 {
+				flush()
+			}
+			dels = append(dels, i)
+			continue
+		case signW == 1 && strings.HasPrefix(line, "+"):
+			adds = append(adds, i)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return out
+}
+
+// maxWordDiffTokens bounds the O(n·m) token comparison per line pair.
+const maxWordDiffTokens = 256
+
+// changedRanges compares two lines token by token (words, whitespace runs,
+// single punctuation) and returns the byte ranges of each that fall outside
+// their longest common subsequence. Lines sharing less than half their
+// text are treated as rewritten and get no emphasis (nil, nil).
+func changedRanges(a, b string) (ra, rb [][2]int) {
+	ta, tb := diffTokens(a), diffTokens(b)
+	if len(ta) > maxWordDiffTokens || len(tb) > maxWordDiffTokens {
+		return nil, nil
+	}
+
+	// lcs[i][j] = LCS length of ta[i:] and tb[j:]
+	lcs := make([][]int, len(ta)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(tb)+1)
+	}
+	for i := len(ta) - 1; i >= 0; i-- {
+		for j := len(tb) - 1; j >= 0; j-- {
+			if ta[i] == tb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	keepA, keepB := make([]bool, len(ta)), make([]bool, len(tb))
+	common := 0
+	for i, j := 0, 0; i < len(ta) && j < len(tb); {
+		switch {
+		case ta[i] == tb[j]:
+			keepA[i], keepB[j] = true, true
+			common += len(ta[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	if common*2 < max(len(a), len(b)) {
+		return nil, nil
+	}
+	return tokenRanges(ta, keepA), tokenRanges(tb, keepB)
+}
+
+// tokenRanges converts unkept tokens into merged byte ranges.
+func tokenRanges(toks []string, keep []bool) [][2]int {
+	var out [][2]int
+	pos := 0
+	for i, t := range toks {
+		if !keep[i] {
+			if n := len(out); n > 0 && out[n-1][1] == pos {
+				out[n-1][1] += len(t)
+			} else {
+				out = append(out, [2]int{pos, pos + len(t)})
+			}
+		}
+		pos += len(t)
+	}
+	return out
+}
+
+// diffTokens splits s into words (letters, digits, _), whitespace runs and
+// single other characters. The tokens concatenate back to s.
+func diffTokens(s string) []string {
+	class := func(r rune) int {
+		switch {
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		default:
+			return 0
+		}
+	}
+	var toks []string
+	start, prev := 0, -1
+	for i, r := range s {
+		c := class(r)
+		if i > start && (c != prev || c == 0) {
+			toks = append(toks, s[start:i])
+			start = i
+		}
+		prev = c
+	}
+	if start < len(s) {
+		toks = append(toks, s[start:])
+	}
+	return toks
+}
+
+// ── Hunk and file navigation ──
+
+// diffFile is a file section within a (multi-file) diff.
+type diffFile struct {
+	name string
+	line int
+}
+
+// diffOutline locates the file headers ("diff …") and hunk headers ("@@")
+// in raw diff lines, as indexes into src.
+func diffOutline(src []string) (files []diffFile, hunks []int) {
+	for i, line := range src {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			files = append(files, diffFile{name: diffPath(line), line: i})
+		case strings.HasPrefix(line, "@@"):
+			hunks = append(hunks, i)
+		}
+	}
+	return files, hunks
+}
+
+// anchorsAbove counts anchors at or above offset: the 1-based index of the
+// section being viewed, or 0 before the first one.
+func anchorsAbove(anchors []int, offset int) int {
+	n := 0
+	for _, a := range anchors {
+		if a <= offset {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"aliz/lz/internal/ui"
+)
+
+// ── Fuzzy filter (/) ──
+
+// matchStyle marks the characters a filter query matched.
+var matchStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+
+// fuzzyMatch reports whether query's runes appear in s in order, ignoring
+// case, and the rune offsets in s that matched. A contiguous match is
+// preferred, so "main" in "domain/main.go" marks the file name.
+func fuzzyMatch(query, s string) ([]int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return nil, true
+	}
+	rs := []rune(s)
+	for i := range rs {
+		rs[i] = unicode.ToLower(rs[i])
+	}
+
+	for i := len(rs) - len(q); i >= 0; i-- {
+		if string(rs[i:i+len(q)]) == string(q) {
+			at := make([]int, len(q))
+			for j := range at {
+				at[j] = i + j
+			}
+			return at, true
+		}
+	}
+	at := make([]int, 0, len(q))
+	for i, r := range rs {
+		if len(at) < len(q) && r == q[len(at)] {
+			at = append(at, i)
+		}
+	}
+	return at, len(at) == len(q)
+}
+
+// mark draws s in base with the runes matching the filter query
+// highlighted. Without a query, or if s doesn't match (it may have been
+// truncated), s is drawn in base.
+func (m gitModel) mark(s string, base lipgloss.Style) string {
+	at, ok := fuzzyMatch(m.query, s)
+	if m.query == "" || !ok {
+		return base.Render(s)
+	}
+	var b strings.Builder
+	rs := []rune(s)
+	start := 0
+	for _, i := range at {
+		if i > start {
+			b.WriteString(base.Render(string(rs[start:i])))
+		}
+		b.WriteString(matchStyle.Inherit(base).Render(string(rs[i])))
+		start = i + 1
+	}
+	if start < len(rs) {
+		b.WriteString(base.Render(string(rs[start:])))
+	}
+	return b.String()
+}
+
+// markPlain is mark for unstyled text.
+func (m gitModel) markPlain(s string) string { return m.mark(s, lipgloss.NewStyle()) }
+
+// filterText is the part of a row the query is matched against.
+func filterText(r row) string {
+	switch r.kind {
+	case rowFile:
+		return r.filePath
+	case rowCommit:
+		return r.commitMsg
+	case rowStash:
+		return r.stashMsg
+	}
+	return r.repoName
+}
+
+// filterRows keeps the rows matching the query, under their headers. A
+// repo whose name matches keeps all its rows; headers left without rows
+// are dropped. Graph lanes are dropped unless their whole repo is kept,
+// since gaps would break them.
+func (m gitModel) filterRows(rows []row) []row {
+	matches := func(s string) bool {
+		_, ok := fuzzyMatch(m.query, s)
+		return ok
+	}
+	var out []row
+	for i := 0; i < len(rows); {
+		end := i + 1
+		for end < len(rows) && rows[end].kind != rowRepo && rows[end].kind != rowDay {
+			end++
+		}
+		head, body := rows[i], rows[i+1:end]
+		hasHead := head.isHeader()
+		if !hasHead {
+			body = rows[i:end]
+		}
+		whole := hasHead && head.kind == rowRepo && matches(head.repoName)
+		var kept []row
+		for _, r := range body {
+			switch {
+			case whole:
+				kept = append(kept, r)
+			case r.kind == rowGraph:
+			case matches(filterText(r)), m.tab == tabTimeline && matches(r.repoName):
+				kept = append(kept, r)
+			}
+		}
+		if whole || len(kept) > 0 {
+			if hasHead {
+				out = append(out, head)
+			}
+			out = append(out, kept...)
+		}
+		i = end
+	}
+	return out
+}
+
+// updateFilter edits the query while / is active. The list narrows on
+// every key; the cursor stays on its row while that still matches.
+func (m gitModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.clearFilter()
+		return m, nil
+	case tea.KeyEnter:
+		m.filtering = false
+		return m, nil
+	case tea.KeyUp:
+		m.cursor = m.moveCursor(m.cursor, -1)
+		return m, nil
+	case tea.KeyDown:
+		m.cursor = m.moveCursor(m.cursor, 1)
+		return m, nil
+	case tea.KeyBackspace:
+		q := []rune(m.query)
+		if len(q) == 0 {
+			return m, nil
+		}
+		m.setQuery(string(q[:len(q)-1]))
+	case tea.KeyRunes, tea.KeySpace:
+		m.setQuery(m.query + string(msg.Runes))
+	}
+	return m, nil
+}
+
+func (m *gitModel) setQuery(q string) {
+	id := m.rowID(m.cursor)
+	m.query = q
+	m.rebuildRows()
+	m.cursor = m.firstContentRow()
+	for i := range m.rows {
+		if !m.rows[i].isHeader() && m.rowID(i) == id {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// clearFilter shows every row again, keeping the cursor on the row it was
+// on.
+func (m *gitModel) clearFilter() {
+	id := m.rowID(m.cursor)
+	m.query, m.filtering = "", false
+	m.rebuildRows()
+	m.restoreCursor(id, m.cursor)
+}
+
+// filterBar is the input line shown in place of the help bar while typing.
+func (m gitModel) filterBar() string {
+	return ui.Bold.Render("/") + m.query + ui.Faint.Render("▏  enter keep · esc clear · ↑/↓ move")
+}
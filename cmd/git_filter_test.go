@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, s string
+		want     []int
+		ok       bool
+	}{
+		{"", "main.go", nil, true},
+		{"main", "domain/main.go", []int{7, 8, 9, 10}, true}, // last contiguous run
+		{"ab", "a_b ab", []int{4, 5}, true},                  // contiguous beats an earlier subsequence
+		{"mg", "main.go", []int{0, 5}, true},                 // else the first subsequence
+		{"README", "readme.md", []int{0, 1, 2, 3, 4, 5}, true},
+		{"é", "café", []int{3}, true}, // rune offsets, not bytes
+		{"gm", "main.go", nil, false},
+		{"xyz", "main.go", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := fuzzyMatch(tt.query, tt.s)
+		if ok != tt.ok || ok && !slices.Equal(got, tt.want) {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, %v; want %v, %v", tt.query, tt.s, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFilterRows(t *testing.T) {
+	repo := func(name string) row { return row{kind: rowRepo, repoName: name} }
+	file := func(repo, path string) row { return row{kind: rowFile, repoName: repo, filePath: path} }
+	commit := func(repo, msg string) row { return row{kind: rowCommit, repoName: repo, commitMsg: msg} }
+	graph := func(repo string) row { return row{kind: rowGraph, repoName: repo} }
+	day := func(label string) row { return row{kind: rowDay, label: label} }
+
+	status := []row{
+		repo("api"), file("api", "main.go"), file("api", "README.md"),
+		repo("web"), file("web", "index.html"),
+		repo("docs"),
+	}
+	commits := []row{
+		repo("api"), commit("api", "feat: login"), graph("api"), commit("api", "fix: logout"),
+		repo("web"), commit("web", "fix: styles"),
+	}
+	timeline := []row{
+		day("Today"), commit("api", "feat: login"), commit("web", "fix: styles"),
+		day("Yesterday"), commit("api", "chore: deps"),
+	}
+
+	tests := []struct {
+		name  string
+		tab   gitTab
+		rows  []row
+		query string
+		want  []string
+	}{
+		{"file under its header", tabStatus, status, "main", []string{"repo api", "file main.go"}},
+		{"headers without matches dropped", tabStatus, status, "html", []string{"repo web", "file index.html"}},
+		{"repo name keeps the repo", tabStatus, status, "web", []string{"repo web", "file index.html"}},
+		{"matching empty repo kept", tabStatus, status, "docs", []string{"repo docs"}},
+		{"no matches", tabStatus, status, "zzz", nil},
+		{"graph rows dropped", tabCommits, commits, "fix", []string{"repo api", "commit fix: logout", "repo web", "commit fix: styles"}},
+		{"graph rows kept with the repo", tabCommits, commits, "api", []string{"repo api", "commit feat: login", "graph", "commit fix: logout"}},
+		{"timeline subject", tabTimeline, timeline, "deps", []string{"day Yesterday", "commit chore: deps"}},
+		{"timeline repo name", tabTimeline, timeline, "web", []string{"day Today", "commit fix: styles"}},
+	}
+	for _, tt := range tests {
+		m := gitModel{tab: tt.tab, query: tt.query}
+		var got []string
+		for _, r := range m.filterRows(tt.rows) {
+			got = append(got, describeRow(r))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: filterRows(%q) = %v, want %v", tt.name, tt.query, got, tt.want)
+		}
+	}
+}
+
+func describeRow(r row) string {
+	switch r.kind {
+	case rowRepo:
+		return "repo " + r.repoName
+	case rowDay:
+		return "day " + r.label
+	case rowGraph:
+		return "graph"
+	case rowFile:
+		return "file " + filterText(r)
+	}
+	return "commit " + filterText(r)
+}
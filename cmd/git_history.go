@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"aliz/lz/internal/git"
+	"aliz/lz/internal/ui"
+)
+
+// ── File history ──
+
+// fileHistory lists the commits that touched one file, opened with H on a
+// file row; enter shows a commit's diff limited to that file.
+type fileHistory struct {
+	repo     string // repo path
+	repoName string
+	path     string // path in the working tree
+	commits  []git.FileCommit
+	cursor   int
+}
+
+func (m *gitModel) openHistory(r row) {
+	path := r.filePath
+	if _, to, ok := strings.Cut(path, " -> "); ok {
+		path = to
+	}
+	repo := m.entries[r.entryIdx].repo.Path
+	m.views = append(m.views, &fileHistory{
+		repo:     repo,
+		repoName: r.repoName,
+		path:     path,
+		commits:  git.FileHistory(repo, path, max(m.opts.limit, defaultTimelineLimit)),
+	})
+}
+
+func (h *fileHistory) update(m *gitModel, key string) tea.Cmd {
+	switch key {
+	case "up", "k":
+		h.cursor = max(h.cursor-1, 0)
+	case "down", "j":
+		h.cursor = max(min(h.cursor+1, len(h.commits)-1), 0)
+	case "g":
+		h.cursor = 0
+	case "G":
+		h.cursor = max(len(h.commits)-1, 0)
+	case "enter", "right", "l":
+		if h.cursor < len(h.commits) {
+			c := h.commits[h.cursor]
+			m.openDiff(h.repoName+" — "+h.path+" @ "+c.Hash+" "+c.Subject, git.ShowCommitFile(h.repo, c.Hash, c.Path, c.OldPath))
+		}
+	case "b":
+		if h.cursor < len(h.commits) {
+			c := h.commits[h.cursor]
+			m.openBlame(h.repo, h.repoName, c.Hash, c.Path, 0)
+		}
+	}
+	return nil
+}
+
+func (h *fileHistory) view(m gitModel) string {
+	var b strings.Builder
+
+	b.WriteString(ui.DetailTitle.Render("← " + h.repoName + " — " + h.path))
+	b.WriteString(ui.Faint.Render("  history"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.width))
+	b.WriteString("\n")
+
+	if len(h.commits) == 0 {
+		b.WriteString(ui.Faint.Render("  No commits touch this file yet."))
+		b.WriteString("\n")
+	}
+
+	hashW, ageW := 0, 0
+	for _, c := range h.commits {
+		hashW = max(hashW, len(c.Hash))
+		ageW = max(ageW, len(ui.RelativeTime(c.Time)))
+	}
+	lines := make([]string, len(h.commits))
+	for i, c := range h.commits {
+		subject := c.Subject
+		if c.Path != h.path {
+			subject += " (" + c.Path + ")"
+		}
+		lines[i] = commitLine("", c.Hash, subject, c.Tag, ui.RelativeTime(c.Time), 0, hashW, ageW, m.width-2, i == h.cursor, nil)
+	}
+	height := max(m.height-4, 1)
+	start := ui.KeepCursorVisible(h.cursor, len(lines), height)
+	for _, l := range lines[start:min(start+height, len(lines))] {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(ui.RenderHelp("↑/↓ select", "enter diff of this file", "b blame", "← back"))
+	return b.String()
+}
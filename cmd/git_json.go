@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ── JSON output (--json, --ndjson) ──
+
+// jsonSchemaVersion is bumped when a field is removed, renamed or changes
+// meaning. New fields may appear within a version. The schema is documented
+// in the README.
+const jsonSchemaVersion = 1
+
+type outputFormat int
+
+const (
+	formatText   outputFormat = iota
+	formatJSON                // one document: {"version", "repos": [...]}
+	formatNDJSON              // one repo object per line, each with "version"
+)
+
+type jsonDoc struct {
+	Version int        `json:"version"`
+	Repos   []jsonRepo `json:"repos"`
+	Error   string     `json:"error,omitempty"`
+}
+
+type jsonRepo struct {
+	Version    int           `json:"version,omitempty"` // NDJSON only
+	Name       string        `json:"name"`
+	Path       string        `json:"path"`
+	Branch     string        `json:"branch"`
+	Detached   bool          `json:"detached"`
+	Head       string        `json:"head"`
+	HeadRef    string        `json:"head_ref"`
+	Unborn     bool          `json:"unborn"`
+	Shallow    bool          `json:"shallow"`
+	Upstream   *jsonDiverge  `json:"upstream"`
+	Base       *jsonDiverge  `json:"base"`
+	Remotes    []jsonDiverge `json:"remotes"`
+	Tag        *jsonTag      `json:"tag"`
+	LastCommit *time.Time    `json:"last_commit"`
+	Clean      bool          `json:"clean"`
+	Files      []jsonFile    `json:"files"`
+	Stashes    []jsonStash   `json:"stashes"`
+	Commits    []jsonCommit  `json:"commits"`
+	Stale      bool          `json:"stale"` // --cached: status predates the repo's index or HEAD
+	Error      string        `json:"error,omitempty"`
+}
+
+// jsonDiverge is HEAD's divergence from another ref.
+type jsonDiverge struct {
+	Name   string `json:"name,omitempty"` // remotes only
+	Ref    string `json:"ref,omitempty"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+}
+
+type jsonTag struct {
+	Name  string `json:"name"`
+	Ahead int    `json:"ahead"`
+}
+
+type jsonFile struct {
+	XY       string `json:"xy"`
+	Path     string `json:"path"`
+	OrigPath string `json:"orig_path,omitempty"`
+}
+
+type jsonStash struct {
+	Index   int       `json:"index"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+type jsonCommit struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Time    time.Time `json:"time"`
+	Tag     string    `json:"tag,omitempty"`
+}
+
+// runGitJSON prints entries for -l, -c and -s in a machine-readable form.
+// Every mode emits the same repo objects; the mode only picks which repos
+// are listed, as in the text output.
+func runGitJSON(opts gitOptions) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+
+	entries, err := gatherEntries(opts)
+	if err != nil {
+		// NDJSON has no document to carry the error: it prints no lines and
+		// the error goes to stderr alone.
+		if opts.format == formatJSON {
+			enc.SetIndent("", "  ")
+			enc.Encode(jsonDoc{Version: jsonSchemaVersion, Repos: []jsonRepo{}, Error: err.Error()})
+		}
+		return err
+	}
+
+	repos := []jsonRepo{}
+	for _, e := range entries {
+		switch {
+		case opts.mode == modeCommits && !opts.filter.IsZero() && len(e.commits) == 0:
+			continue
+		case opts.mode == modeStash && len(e.status.Stashes) == 0:
+			continue
+		}
+		repos = append(repos, toJSONRepo(e))
+	}
+
+	if opts.format == formatNDJSON {
+		for _, r := range repos {
+			r.Version = jsonSchemaVersion
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonDoc{Version: jsonSchemaVersion, Repos: repos})
+}
+
+func toJSONRepo(e repoEntry) jsonRepo {
+	s := e.status
+	r := jsonRepo{
+		Name:     e.repo.Name,
+		Path:     e.repo.Path,
+		Branch:   s.Branch,
+		Detached: s.Detached,
+		Head:     s.Head,
+		HeadRef:  s.HeadRef,
+		Unborn:   s.Unborn,
+		Shallow:  s.Shallow,
+		Remotes:  []jsonDiverge{},
+		Clean:    s.IsClean,
+		Stale:    e.stale,
+		Files:    []jsonFile{},
+		Stashes:  []jsonStash{},
+		Commits:  []jsonCommit{},
+	}
+	if s.Err != nil {
+		r.Error = s.Err.Error()
+	}
+	if s.HasUpstream {
+		r.Upstream = &jsonDiverge{Ahead: s.Ahead, Behind: s.Behind}
+	}
+	if s.Base != "" {
+		r.Base = &jsonDiverge{Ref: s.Base, Ahead: s.BaseAhead, Behind: s.BaseBehind}
+	}
+	for _, rs := range s.Remotes {
+		r.Remotes = append(r.Remotes, jsonDiverge{Name: rs.Name, Ref: rs.Ref, Ahead: rs.Ahead, Behind: rs.Behind})
+	}
+	if s.Tag != "" {
+		r.Tag = &jsonTag{Name: s.Tag, Ahead: s.TagAhead}
+	}
+	if !s.Age.IsZero() {
+		r.LastCommit = &s.Age
+	}
+	for _, f := range s.Files {
+		jf := jsonFile{XY: f.XY, Path: f.File}
+		if from, to, ok := strings.Cut(f.File, " -> "); ok {
+			jf.OrigPath, jf.Path = from, to
+		}
+		r.Files = append(r.Files, jf)
+	}
+	for _, st := range s.Stashes {
+		idx, _ := strconv.Atoi(st.Index)
+		r.Stashes = append(r.Stashes, jsonStash{Index: idx, Message: st.Message, Time: st.Time})
+	}
+	for _, c := range e.commits {
+		r.Commits = append(r.Commits, jsonCommit{Hash: c.Hash, Subject: c.Subject, Time: c.Time, Tag: c.Tag})
+	}
+	return r
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"os"
+
+	"aliz/lz/internal/prompt"
+)
+
+// ── Shell prompt (lz g prompt) ──
+
+// runGitPrompt prints the prompt segment. lz-prompt prints the same without
+// lz's startup cost, for prompts redrawn after every command, and reads the
+// same flags.
+func runGitPrompt(args []string) error {
+	opts, err := prompt.ParseArgs(args)
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	return prompt.Run(os.Stdout, cwd, opts)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"aliz/lz/internal/git"
+)
+
+// ── Standup / weekly report (lz g report) ──
+
+// defaultReportSince is the report window when --since is not given.
+const defaultReportSince = "1w"
+
+func runGitReport(opts gitOptions) error {
+	if opts.filter.Since == "" {
+		opts.filter.Since = gitDate(defaultReportSince, time.Now())
+	}
+	entries, err := gatherEntries(opts)
+	if err != nil {
+		return err
+	}
+
+	report := buildReport(entries, opts.filter, unpushedCommits(entries))
+	if opts.output == "" {
+		fmt.Print(report)
+		return nil
+	}
+	if err := os.WriteFile(opts.output, []byte(report), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s\n", opts.output)
+	return nil
+}
+
+// unpushedCommits lists commits on HEAD not yet on its upstream, per entry.
+func unpushedCommits(entries []repoEntry) [][]git.Commit {
+	out := make([][]git.Commit, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		if !e.status.HasUpstream || e.status.Ahead == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out[i] = git.CommitPage(e.repo.Path, git.LogFilter{Range: "@{upstream}..HEAD"}, false, 0, e.status.Ahead)
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+// buildReport renders a Markdown summary grouped by repo: commits matching
+// f, uncommitted changes, unpushed commits and stashes. Repos with nothing
+// to report are left out.
+func buildReport(entries []repoEntry, f git.LogFilter, unpushed [][]git.Commit) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Report — %s\n\n", time.Now().Format("Mon 2 Jan 2006"))
+	fmt.Fprintf(&b, "_%s_\n", describeFilter(f))
+
+	commit := func(c git.Commit) string {
+		line := fmt.Sprintf("- `%s` %s", c.Hash, c.Subject)
+		if c.Tag != "" {
+			line += " (" + c.Tag + ")"
+		}
+		return line + " — " + c.Time.Format("Mon 2 Jan 15:04") + "\n"
+	}
+
+	reported := 0
+	for i, e := range entries {
+		s := e.status
+		if len(e.commits) == 0 && s.IsClean && len(unpushed[i]) == 0 && len(s.Stashes) == 0 {
+			continue
+		}
+		reported++
+
+		fmt.Fprintf(&b, "\n## %s\n\n", e.repo.Name)
+		fmt.Fprintf(&b, "`%s`", branchLabel(s))
+		if s.Tag != "" {
+			fmt.Fprintf(&b, " · %s", s.Tag)
+			if s.TagAhead > 0 {
+				fmt.Fprintf(&b, " +%d", s.TagAhead)
+			}
+		}
+		b.WriteString("\n")
+
+		if len(e.commits) > 0 {
+			fmt.Fprintf(&b, "\n### Commits (%d)\n\n", len(e.commits))
+			for _, c := range e.commits {
+				b.WriteString(commit(c))
+			}
+		}
+		if len(unpushed[i]) > 0 {
+			fmt.Fprintf(&b, "\n### Unpushed (%d)\n\n", len(unpushed[i]))
+			for _, c := range unpushed[i] {
+				b.WriteString(commit(c))
+			}
+		}
+		if !s.IsClean {
+			fmt.Fprintf(&b, "\n### Uncommitted (%d)\n\n", len(s.Files))
+			for _, fs := range s.Files {
+				ch, _ := fileSign(fs.XY)
+				fmt.Fprintf(&b, "- `%c` %s\n", ch, fs.File)
+			}
+		}
+		if len(s.Stashes) > 0 {
+			fmt.Fprintf(&b, "\n### Stashes (%d)\n\n", len(s.Stashes))
+			for _, st := range s.Stashes {
+				fmt.Fprintf(&b, "- `stash@{%s}` %s — %s\n", st.Index, st.Message, st.Time.Format("Mon 2 Jan 15:04"))
+			}
+		}
+	}
+	if reported == 0 {
+		b.WriteString("\nNothing to report.\n")
+	}
+	return b.String()
+}
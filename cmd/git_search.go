@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"aliz/lz/internal/git"
+	"aliz/lz/internal/ui"
+)
+
+// ── Commit search (s) ──
+
+// searchKeys are the fields of a search, in the order they are shown. Each
+// is the lz g flag of the same name.
+var searchKeys = []string{"author", "since", "until", "grep", "path", "range"}
+
+// formatSearch writes f as the search input edits it:
+// `author:ana grep:"login bug"`.
+func formatSearch(f git.LogFilter) string {
+	var parts []string
+	for _, k := range searchKeys {
+		v := *filterField(&f, "--"+k)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, " ") {
+			v = `"` + v + `"` // values can't hold quotes themselves
+		}
+		parts = append(parts, k+":"+v)
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseSearch reads key:value words as formatSearch writes them. Values with
+// spaces are quoted. Dates take the same shorthands as --since and --until.
+func parseSearch(s string, now time.Time) (git.LogFilter, error) {
+	var f git.LogFilter
+	var words []string
+	var word strings.Builder
+	quoted, inWord := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted, inWord = !quoted, true
+		case r == ' ' && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+			}
+			inWord = false
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted {
+		return f, fmt.Errorf("unclosed quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	for _, w := range words {
+		k, v, ok := strings.Cut(w, ":")
+		if !ok || v == "" {
+			return f, fmt.Errorf("want key:value, got %q", w)
+		}
+		switch k {
+		case "author", "grep", "path":
+		case "since", "until":
+			v = gitDate(v, now)
+		case "range":
+			if strings.HasPrefix(v, "-") {
+				return f, fmt.Errorf("range: not a revision range: %q", v)
+			}
+		default:
+			return f, fmt.Errorf("unknown field %q (want %s)", k, strings.Join(searchKeys, ", "))
+		}
+		*filterField(&f, "--"+k) = v
+	}
+	return f, nil
+}
+
+// startSearch opens the search input on the current filter.
+func (m *gitModel) startSearch() {
+	m.searching, m.search, m.searchErr = true, formatSearch(m.opts.filter), ""
+}
+
+// updateSearch edits the search input. enter applies it and reloads every
+// repo's commits; an empty search clears the filter.
+func (m gitModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.searching = false
+	case tea.KeyEnter:
+		f, err := parseSearch(m.search, time.Now())
+		if err != nil {
+			m.searchErr = err.Error()
+			return m, nil
+		}
+		m.searching = false
+		if f == m.opts.filter {
+			return m, nil
+		}
+		if f.Range != "" {
+			m.opts.branches = false
+		}
+		m.opts.filter = f
+		m.rebuildRows()
+		m.cursor = m.firstContentRow()
+		return m, m.reloadCommits()
+	case tea.KeyCtrlU:
+		m.search, m.searchErr = "", ""
+	case tea.KeyBackspace:
+		if s := []rune(m.search); len(s) > 0 {
+			m.search, m.searchErr = string(s[:len(s)-1]), ""
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search, m.searchErr = m.search+string(msg.Runes), ""
+	}
+	return m, nil
+}
+
+// searchBar is the input line shown in place of the help bar while editing.
+func (m gitModel) searchBar() string {
+	bar := ui.Bold.Render("search ") + m.search + ui.Faint.Render("▏")
+	if m.searchErr != "" {
+		return bar + "  " + ui.Red.Render(m.searchErr)
+	}
+	return bar + ui.Faint.Render("  author: since: until: grep: path: range: · enter apply · ctrl+u clear · esc cancel")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"aliz/lz/internal/git"
+)
+
+func TestParseSearch(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in      string
+		want    git.LogFilter
+		wantErr bool
+	}{
+		{"", git.LogFilter{}, false},
+		{"author:ana grep:fix", git.LogFilter{Author: "ana", Grep: "fix"}, false},
+		{`grep:"login bug"  path:api/`, git.LogFilter{Grep: "login bug", Path: "api/"}, false},
+		{"since:1w", git.LogFilter{Since: "1 week ago"}, false},
+		{"range:main..feature", git.LogFilter{Range: "main..feature"}, false},
+		{"range:-p", git.LogFilter{}, true},
+		{"fix", git.LogFilter{}, true},
+		{"grep:", git.LogFilter{}, true},
+		{"color:red", git.LogFilter{}, true},
+		{`grep:"open`, git.LogFilter{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSearch(tt.in, now)
+		if (err != nil) != tt.wantErr || err == nil && got != tt.want {
+			t.Errorf("parseSearch(%q) = %+v, %v; want %+v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatSearchRoundTrip(t *testing.T) {
+	f := git.LogFilter{Author: "ana", Since: "1 week ago", Grep: "login bug", Range: "main..feature"}
+	got, err := parseSearch(formatSearch(f), time.Now())
+	if err != nil || got != f {
+		t.Errorf("parseSearch(formatSearch(%+v)) = %+v, %v", f, got, err)
+	}
+}
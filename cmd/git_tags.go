@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+
+	"aliz/lz/internal/git"
+	"aliz/lz/internal/ui"
+)
+
+// ── Tags and releases ──
+
+// tagsView lists a repo's tags, opened with t. r starts a release: pick the
+// next semver version, then create an annotated tag and optionally push it.
+type tagsView struct {
+	repo     string // repo path
+	repoName string
+	status   git.RepoStatus
+	tags     []git.TagInfo
+	counted  bool // tags' Since is filled in
+	cursor   int
+
+	release *release // non-nil while choosing a version
+	result  string   // outcome of the last release
+	failed  bool
+}
+
+// release proposes the next patch, minor and major versions after from,
+// with the changelog since as the tag message.
+type release struct {
+	from      string
+	versions  [3]string
+	choice    int
+	changelog changelog
+	pending   bool // tag being created or pushed
+}
+
+// releaseMsg reports a finished tag (and push).
+type releaseMsg struct {
+	repo   string
+	tag    string
+	remote string // "" when not pushed
+	err    error
+}
+
+// tagCountMsg delivers commits-since counts for a repo's tags.
+type tagCountMsg struct {
+	repo   string
+	counts map[string]int
+}
+
+func (m *gitModel) openTags(e repoEntry) tea.Cmd {
+	t := &tagsView{
+		repo:     e.repo.Path,
+		repoName: e.repo.Name,
+		status:   e.status,
+		tags:     git.Tags(e.repo.Path),
+	}
+	m.views = append(m.views, t)
+	return t.countSince()
+}
+
+// countSince counts commits since each tag in the background: it takes a
+// git run per tag.
+func (t *tagsView) countSince() tea.Cmd {
+	t.counted = false
+	repo, tags := t.repo, t.tags
+	return func() tea.Msg {
+		return tagCountMsg{repo: repo, counts: git.CountSince(repo, tags)}
+	}
+}
+
+func (t *tagsView) setCounts(counts map[string]int) {
+	for i := range t.tags {
+		t.tags[i].Since = counts[t.tags[i].Name]
+	}
+	t.counted = true
+}
+
+// modal keeps back keys from closing the view mid-release.
+func (t *tagsView) modal() bool { return t.release != nil }
+
+func (t *tagsView) update(m *gitModel, key string) tea.Cmd {
+	if r := t.release; r != nil {
+		if r.pending {
+			return nil
+		}
+		switch key {
+		case "esc", "q":
+			t.release = nil
+		case "left", "h":
+			r.choice = max(r.choice-1, 0)
+		case "right", "l", "tab":
+			r.choice = min(r.choice+1, len(r.versions)-1)
+		case "1", "2", "3":
+			r.choice = int(key[0] - '1')
+		case "enter", "P":
+			r.pending = true
+			return createRelease(t.repo, r.versions[r.choice], r.message(), key == "P")
+		}
+		return nil
+	}
+
+	switch key {
+	case "up", "k":
+		t.cursor = max(t.cursor-1, 0)
+	case "down", "j":
+		t.cursor = max(min(t.cursor+1, len(t.tags)-1), 0)
+	case "g":
+		t.cursor = 0
+	case "G":
+		t.cursor = max(len(t.tags)-1, 0)
+	case "enter", "right", "l":
+		if t.cursor < len(t.tags) {
+			tag := t.tags[t.cursor]
+			m.openCommit(t.repo, t.repoName, tag.Hash, tag.Subject)
+		}
+	case "r":
+		t.startRelease()
+	}
+	return nil
+}
+
+// startRelease proposes versions after the tag nearest HEAD (or the newest
+// semver tag), unless HEAD is already tagged.
+func (t *tagsView) startRelease() {
+	t.result, t.failed = "", false
+	if t.status.Tag != "" && t.status.TagAhead == 0 {
+		t.result, t.failed = "HEAD is already tagged "+t.status.Tag, true
+		return
+	}
+	from := t.status.Tag
+	if _, ok := parseSemver(from); !ok {
+		from = ""
+		for _, tag := range t.tags {
+			if _, ok := parseSemver(tag.Name); ok {
+				from = tag.Name
+				break
+			}
+		}
+	}
+
+	r := &release{from: from}
+	v, ok := parseSemver(from)
+	if !ok {
+		v = semver{prefix: "v"} // first release: v0.0.1 / v0.1.0 / v1.0.0
+	}
+	for i := range r.versions {
+		r.versions[i] = v.bump(i).String()
+	}
+	r.changelog = buildChangelog(git.CommitsBetween(t.repo, from, "HEAD"))
+	r.choice = r.changelog.bump()
+	t.release = r
+}
+
+func createRelease(repo, tag, message string, push bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.CreateTag(repo, tag, message); err != nil {
+			return releaseMsg{repo: repo, tag: tag, err: err}
+		}
+		if !push {
+			return releaseMsg{repo: repo, tag: tag}
+		}
+		remote := git.PushRemote(repo)
+		return releaseMsg{repo: repo, tag: tag, remote: remote, err: git.PushTag(repo, remote, tag)}
+	}
+}
+
+// created reports whether the tag exists, even if pushing it failed.
+func (msg releaseMsg) created() bool { return msg.err == nil || msg.remote != "" }
+
+// finish records a release result and reloads the tag list.
+func (t *tagsView) finish(msg releaseMsg) tea.Cmd {
+	t.release = nil
+	t.tags = git.Tags(t.repo)
+	if msg.created() {
+		t.status.Tag, t.status.TagAhead = msg.tag, 0
+	}
+	switch {
+	case msg.err != nil && msg.remote != "":
+		t.result, t.failed = "created "+msg.tag+", push to "+msg.remote+" failed: "+firstLine(msg.err.Error()), true
+	case msg.err != nil:
+		t.result, t.failed = firstLine(msg.err.Error()), true
+	case msg.remote != "":
+		t.result = "created " + msg.tag + " and pushed to " + msg.remote
+	default:
+		t.result = "created " + msg.tag
+	}
+	return t.countSince()
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// message is the annotated tag message: a title and the changelog since the
+// previous release.
+func (r *release) message() string {
+	return "Release " + r.versions[r.choice] + "\n" + r.changelog.markdown("##")
+}
+
+func (t *tagsView) view(m gitModel) string {
+	var b strings.Builder
+
+	b.WriteString(ui.DetailTitle.Render("← " + t.repoName))
+	b.WriteString(ui.Faint.Render("  tags"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.width))
+	b.WriteString("\n")
+
+	var lines []string
+	if r := t.release; r != nil {
+		lines = t.releaseLines(m.width)
+	} else {
+		if t.result != "" {
+			style := ui.Green
+			if t.failed {
+				style = ui.Red
+			}
+			lines = append(lines, style.Render("  "+t.result), "")
+		}
+		if len(t.tags) == 0 {
+			lines = append(lines, ui.Faint.Render("  No tags yet."))
+		}
+		lines = append(lines, t.tagLines(m.width)...)
+	}
+
+	height := max(m.height-4, 1)
+	cursorLine := t.cursor
+	if t.result != "" && t.release == nil {
+		cursorLine += 2
+	}
+	start := ui.KeepCursorVisible(cursorLine, len(lines), height)
+	for _, l := range lines[start:min(start+height, len(lines))] {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+
+	switch {
+	case t.release != nil && t.release.pending:
+		b.WriteString(ui.RenderHelp("creating " + t.release.versions[t.release.choice] + " …"))
+	case t.release != nil:
+		b.WriteString(ui.RenderHelp("←/→ version", "enter create tag", "P create and push", "esc cancel"))
+	default:
+		b.WriteString(ui.RenderHelp("↑/↓ select", "enter commit", "r release", "← back"))
+	}
+	return b.String()
+}
+
+// tagLines renders "  name  date  age  +N  hash subject" per tag.
+func (t *tagsView) tagLines(width int) []string {
+	nameW, sinceW := 0, 0
+	for _, tag := range t.tags {
+		nameW = max(nameW, runewidth.StringWidth(tag.Name))
+		sinceW = max(sinceW, len(strconv.Itoa(tag.Since))+1)
+	}
+	lines := make([]string, len(t.tags))
+	for i, tag := range t.tags {
+		name := tag.Name + strings.Repeat(" ", nameW-runewidth.StringWidth(tag.Name))
+		date := tag.Time.Format("2 Jan 2006")
+		age := fmt.Sprintf("%4s", ui.RelativeTime(tag.Time))
+		since := fmt.Sprintf("%*s", sinceW, "+"+strconv.Itoa(tag.Since))
+		if !t.counted {
+			since = fmt.Sprintf("%*s", sinceW, "…")
+		}
+		subjectW := max(width-4-nameW-2-11-2-4-2-sinceW-2-len(tag.Hash)-1, 10)
+		subject := ui.Truncate(tag.Subject, subjectW)
+
+		if i == t.cursor {
+			lines[i] = ui.Cursor.Render("  ▸ " + name + "  " + fmt.Sprintf("%11s", date) + "  " + age + "  " + since + "  " + tag.Hash + " " + subject)
+			continue
+		}
+		sinceStyle := ui.Yellow
+		switch {
+		case !t.counted:
+			sinceStyle = ui.Faint
+		case tag.Since == 0:
+			sinceStyle = ui.Green
+		}
+		lines[i] = "    " + ui.Green.Render(name) + "  " + ui.Faint.Render(fmt.Sprintf("%11s", date)+"  "+age) + "  " +
+			sinceStyle.Render(since) + "  " + ui.Yellow.Render(tag.Hash) + " " + subject
+	}
+	return lines
+}
+
+// releaseLines shows the version choice and the tag message to be written.
+func (t *tagsView) releaseLines(width int) []string {
+	r := t.release
+	from := "no previous release"
+	if r.from != "" {
+		from = "after " + r.from
+	}
+	lines := []string{
+		ui.Bold.Render("  New release") + ui.Faint.Render(fmt.Sprintf(" — %s, %d commits", from, r.changelog.total)),
+		"",
+	}
+
+	var choices []string
+	for i, v := range r.versions {
+		label := [3]string{"patch", "minor", "major"}[i] + " " + v
+		if i == r.choice {
+			choices = append(choices, ui.Cursor.Render("▸ "+label))
+		} else {
+			choices = append(choices, ui.Faint.Render("  "+label))
+		}
+	}
+	lines = append(lines, "  "+strings.Join(choices, "   "), "")
+
+	for _, l := range strings.Split(strings.TrimRight(r.message(), "\n"), "\n") {
+		lines = append(lines, "    "+ui.Truncate(l, max(width-4, 1)))
+	}
+	return lines
+}
+
+// ── Semantic versions ──
+
+// semver is a MAJOR.MINOR.PATCH tag; prefix keeps a leading "v" and pre
+// any pre-release ("rc.1").
+type semver struct {
+	prefix              string
+	major, minor, patch int
+	pre                 string
+}
+
+// parseSemver reads tags like "v1.2.3", "1.2.3" or "v1.2.3-rc.1". Build
+// metadata ("+build") is dropped.
+func parseSemver(tag string) (semver, bool) {
+	var v semver
+	s := tag
+	if strings.HasPrefix(s, "v") {
+		v.prefix, s = "v", s[1:]
+	}
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+// bump returns the next version: part 0 = patch, 1 = minor, 2 = major.
+// A pre-release is followed by its own release when that is at least the
+// part asked for: v1.1.0-rc.1 bumps to v1.1.0 for a patch or minor.
+func (v semver) bump(part int) semver {
+	pre := v.pre != ""
+	v.pre = ""
+	switch {
+	case part == 0:
+		if !pre {
+			v.patch++
+		}
+	case part == 1:
+		if !pre || v.patch != 0 {
+			v.minor, v.patch = v.minor+1, 0
+		}
+	default:
+		if !pre || v.minor != 0 || v.patch != 0 {
+			v.major, v.minor, v.patch = v.major+1, 0, 0
+		}
+	}
+	return v
+}
+
+func (v semver) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.prefix, v.major, v.minor, v.patch)
+	if v.pre != "" {
+		s += "-" + v.pre
+	}
+	return s
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import "testing"
+
+func TestSemverBump(t *testing.T) {
+	tests := []struct {
+		tag                 string
+		patch, minor, major string
+	}{
+		{"v1.2.3", "v1.2.4", "v1.3.0", "v2.0.0"},
+		{"1.2.3+build.7", "1.2.4", "1.3.0", "2.0.0"},
+		{"v1.0.0-rc.1", "v1.0.0", "v1.0.0", "v1.0.0"},
+		{"v1.1.0-beta", "v1.1.0", "v1.1.0", "v2.0.0"},
+		{"v1.1.2-rc.2+sha.5", "v1.1.2", "v1.2.0", "v2.0.0"},
+	}
+	for _, tt := range tests {
+		v, ok := parseSemver(tt.tag)
+		if !ok {
+			t.Errorf("parseSemver(%q) failed", tt.tag)
+			continue
+		}
+		for part, want := range []string{tt.patch, tt.minor, tt.major} {
+			if got := v.bump(part).String(); got != want {
+				t.Errorf("%s bump(%d) = %s, want %s", tt.tag, part, got, want)
+			}
+		}
+	}
+	for _, tag := range []string{"v1.2", "release", "v1.x.3", ""} {
+		if _, ok := parseSemver(tag); ok {
+			t.Errorf("parseSemver(%q): want !ok", tag)
+		}
+	}
+}
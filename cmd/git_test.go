@@ -0,0 +1,29 @@
+package cmd
+
+import "testing"
+
+func TestParseGitArgs(t *testing.T) {
+	opts, err := parseGitArgs([]string{"changelog", "log", "--to", "v1.0.0", "-n", "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.mode != modeChangelog || opts.repo != "log" || opts.to != "v1.0.0" {
+		t.Errorf("changelog log: mode %d, repo %q, to %q", opts.mode, opts.repo, opts.to)
+	}
+
+	for _, args := range [][]string{
+		{"changelog", "api", "web"}, // one repo
+		{"report", "log"},           // one subcommand
+		{"-l", "api"},
+		{"-c", "log"},
+		{"-l", "--from", "v1.0.0"},
+		{"log", "--to", "v1.0.0"},
+		{"-c", "-o", "out.md"},
+		{"--shell", "zsh"},
+		{"report", "-a"},
+	} {
+		if _, err := parseGitArgs(args); err == nil {
+			t.Errorf("parseGitArgs(%q): want an error", args)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"aliz/lz/internal/ui"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// ── Cross-repo timeline (lz g log, Timeline tab) ──
+
+// defaultTimelineLimit is the per-repo commit cap for lz g log when -n is
+// not given; timelines are usually bounded by --since instead.
+const defaultTimelineLimit = 200
+
+func runGitLog(opts gitOptions) error {
+	entries, err := gatherEntries(opts)
+	if err != nil {
+		return err
+	}
+	rows := flattenTimelineRows(entries)
+	if len(rows) == 0 {
+		fmt.Println("No matching commits.")
+		return nil
+	}
+
+	lay := computeTimelineLayout(rows, entries)
+	for i, r := range rows {
+		if r.kind == rowDay {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(ui.Faint.Render("── ") + ui.Bold.Render(r.label))
+			continue
+		}
+		fmt.Println(lay.render(r, "   ", lay.width, false, nil))
+	}
+	return nil
+}
+
+// flattenTimelineRows interleaves every repo's loaded commits newest first,
+// with a day separator row before each new calendar day.
+func flattenTimelineRows(entries []repoEntry) []row {
+	var commits []row
+	for i, e := range entries {
+		for _, c := range e.commits {
+			commits = append(commits, row{
+				kind:       rowCommit,
+				entryIdx:   i,
+				repoName:   e.repo.Name,
+				commitHash: c.Hash,
+				commitMsg:  c.Subject,
+				commitTime: c.Time,
+				commitTag:  c.Tag,
+			})
+		}
+	}
+	slices.SortStableFunc(commits, func(a, b row) int {
+		return cmp.Or(b.commitTime.Compare(a.commitTime), cmp.Compare(a.repoName, b.repoName))
+	})
+
+	var rows []row
+	var day string
+	for _, c := range commits {
+		if d := dayLabel(c.commitTime); d != day {
+			day = d
+			rows = append(rows, row{kind: rowDay, entryIdx: c.entryIdx, label: d})
+		}
+		rows = append(rows, c)
+	}
+	return rows
+}
+
+// dayLabel names a commit's local calendar day for timeline separators.
+func dayLabel(t time.Time) string {
+	now := time.Now()
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+	// Compare dates, not durations: a day across a DST change is 23 or 25h.
+	today := day(now)
+	switch d := day(t); {
+	case d.Equal(today):
+		return "Today"
+	case d.Equal(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+	case t.Year() == now.Year():
+		return t.Format("Mon 2 Jan")
+	default:
+		return t.Format("Mon 2 Jan 2006")
+	}
+}
+
+// timelineLayout holds column widths for timeline commit rows:
+// "hash  repo  subject···tag  age".
+type timelineLayout struct {
+	hashW, nameW, ageW int
+	width              int // natural row width excluding the 4-cell prefix
+}
+
+func computeTimelineLayout(rows []row, entries []repoEntry) timelineLayout {
+	var l timelineLayout
+	for _, e := range entries {
+		l.nameW = max(l.nameW, runewidth.StringWidth(e.repo.Name))
+	}
+	for _, r := range rows {
+		if r.kind == rowCommit {
+			l.hashW = max(l.hashW, len(r.commitHash))
+			l.ageW = max(l.ageW, len(ui.RelativeTime(r.commitTime)))
+		}
+	}
+	l.width = 60
+	for _, r := range rows {
+		if r.kind == rowCommit {
+			w := l.hashW + l.nameW + l.ageW + 6 + runewidth.StringWidth(r.commitMsg)
+			if r.commitTag != "" {
+				w += runewidth.StringWidth("@" + r.commitTag)
+			}
+			l.width = max(l.width, w)
+		}
+	}
+	return l
+}
+
+// render draws one timeline commit row of the given width after prefix.
+// mark, if set, styles the repo name and subject of non-cursor rows.
+func (l timelineLayout) render(r row, prefix string, width int, cursor bool, mark func(string, lipgloss.Style) string) string {
+	hashPad := strings.Repeat(" ", max(l.hashW-len(r.commitHash), 0))
+	name := r.repoName + strings.Repeat(" ", max(l.nameW-runewidth.StringWidth(r.repoName), 0))
+	age := ui.RelativeTime(r.commitTime)
+
+	var tagPlain string
+	if r.commitTag != "" {
+		tagPlain = "@" + r.commitTag
+	}
+	subjectW := max(width-l.hashW-l.nameW-l.ageW-6-runewidth.StringWidth(tagPlain), 10)
+	subject := ui.Truncate(r.commitMsg, subjectW)
+	dots := strings.Repeat("·", max(subjectW-runewidth.StringWidth(subject), 0))
+
+	if cursor {
+		return ui.Cursor.Render(prefix + r.commitHash + hashPad + "  " + name + "  " + subject + dots + tagPlain + "  " + age)
+	}
+	if mark == nil {
+		mark = func(s string, style lipgloss.Style) string { return style.Render(s) }
+	}
+	return prefix + ui.Yellow.Render(r.commitHash) + hashPad + "  " + mark(name, ui.Cyan) + "  " +
+		mark(subject, lipgloss.NewStyle()) + ui.Faint.Render(dots) + ui.Green.Render(tagPlain) + "  " + ui.Faint.Render(age)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"aliz/lz/internal/git"
+)
+
+// ── Watch mode (--watch) ──
+
+// watchInterval is how often --watch polls each repo. A change is picked up
+// once its repo has been quiet for one more interval, so a burst of writes
+// (a checkout, a rebase, an editor saving several files) refreshes once.
+const watchInterval = time.Second
+
+// watchMsg carries every repo's fingerprint from one poll.
+type watchMsg struct {
+	prints map[string]string // by repo path
+}
+
+// watcher tracks fingerprints between polls.
+type watcher struct {
+	prints   map[string]string
+	settling map[string]bool // changed on the last poll, refresh once quiet
+}
+
+func newWatcher() *watcher {
+	return &watcher{prints: map[string]string{}, settling: map[string]bool{}}
+}
+
+// poll fingerprints every repo in parallel after watchInterval.
+func poll(paths []string) tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		prints := make([]string, len(paths))
+		var wg sync.WaitGroup
+		for i, p := range paths {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				prints[i] = git.Fingerprint(p)
+			}()
+		}
+		wg.Wait()
+
+		msg := watchMsg{prints: make(map[string]string, len(paths))}
+		for i, p := range paths {
+			msg.prints[p] = prints[i]
+		}
+		return msg
+	})
+}
+
+func (m gitModel) repoPaths() []string {
+	paths := make([]string, len(m.entries))
+	for i, e := range m.entries {
+		paths[i] = e.repo.Path
+	}
+	return paths
+}
+
+// handleWatch compares a poll with the last one and refreshes repos whose
+// fingerprint changed and then held still. A repo's first poll is the
+// baseline: the TUI has just read it.
+func (m *gitModel) handleWatch(msg watchMsg) tea.Cmd {
+	w := m.watch
+	var cmds []tea.Cmd
+	for path, fp := range msg.prints {
+		old, seen := w.prints[path]
+		w.prints[path] = fp
+		switch {
+		case !seen:
+		case fp != old:
+			w.settling[path] = true
+		case w.settling[path]:
+			delete(w.settling, path)
+			cmds = append(cmds, m.refreshRepo(path))
+		}
+	}
+	cmds = append(cmds, poll(m.repoPaths()))
+	return tea.Batch(cmds...)
+}
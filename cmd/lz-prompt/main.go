@@ -0,0 +1,31 @@
+// lz-prompt is lz g prompt as a binary of its own. lz links the TUI
+// libraries, whose package initialization alone takes longer than a prompt
+// can afford; lz-prompt links only the standard library and internal/git.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"aliz/lz/internal/prompt"
+)
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	opts, err := prompt.ParseArgs(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	return prompt.Run(os.Stdout, cwd, opts)
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"aliz/lz/internal/ui"
+)
+
+// ── Spinner ──
+
+// spinMsg advances a TUI's spinner while a background reload runs. Models
+// keep ticking until the reload lands.
+type spinMsg struct{}
+
+func spin() tea.Cmd {
+	return tea.Tick(ui.SpinnerInterval, func(time.Time) tea.Msg { return spinMsg{} })
+}
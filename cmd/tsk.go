@@ -354,6 +354,9 @@ type tskModel struct {
 	rendered    string
 	detailTitle string
 	styleOpt    glamour.TermRendererOption
+	refreshing  bool // an r reload is in flight
+	reloads     int  // counts reloads, so an r reload overtaken by another is dropped
+	spinner     int  // spinner frame while refreshing
 	width       int
 	height      int
 }
@@ -419,6 +422,41 @@ func computeTskLayout(tasks []Task, cursorCol bool) tskLayout {
 
 type editorDoneMsg struct{ err error }
 type renderDoneMsg struct{ rendered string }
+type tskReloadMsg struct {
+	tasks []Task
+	seq   int // tskModel.reloads when the scan started
+}
+
+// refresh rescans the task directories in the background.
+func (m *tskModel) refresh() tea.Cmd {
+	if m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	m.reloads++
+	root, seq := m.root, m.reloads
+	return tea.Batch(spin(), func() tea.Msg {
+		return tskReloadMsg{tasks: discoverTasks(root), seq: seq}
+	})
+}
+
+// reload swaps in tasks, keeping the cursor on the same task file when it
+// is still listed, else near its old position.
+func (m *tskModel) reload(tasks []Task) {
+	cursor, path := m.cursor, ""
+	if cursor < len(m.filtered) {
+		path = m.filtered[cursor].Path
+	}
+	m.allTasks = tasks
+	m.applyFilter()
+	m.cursor = max(min(cursor, len(m.filtered)-1), 0)
+	for i, t := range m.filtered {
+		if t.Path == path {
+			m.cursor = i
+			break
+		}
+	}
+}
 
 func (m tskModel) openEditor() tea.Cmd {
 	if len(m.filtered) == 0 {
@@ -445,15 +483,17 @@ func (m tskModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.detail.Total = len(strings.Split(strings.TrimRight(m.rendered, "\n"), "\n"))
 	case editorDoneMsg:
 		m.viewing = false
-		cursor := m.cursor
-		m.allTasks = discoverTasks(m.root)
-		m.applyFilter()
-		m.cursor = cursor
-		if m.cursor >= len(m.filtered) {
-			m.cursor = len(m.filtered) - 1
+		m.reloads++
+		m.reload(discoverTasks(m.root))
+	case tskReloadMsg:
+		m.refreshing = false
+		if msg.seq == m.reloads {
+			m.reload(msg.tasks)
 		}
-		if m.cursor < 0 {
-			m.cursor = 0
+	case spinMsg:
+		if m.refreshing {
+			m.spinner++
+			return m, spin()
 		}
 	case tea.KeyMsg:
 		if m.viewing {
@@ -508,6 +548,8 @@ func (m tskModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "e":
 		return m, m.openEditor()
+	case "r":
+		return m, m.refresh()
 	}
 	return m, nil
 }
@@ -539,6 +581,9 @@ func (m tskModel) viewList() string {
 	var b strings.Builder
 
 	b.WriteString(ui.RenderTabBar([]string{"Active", "Backlog", "Done", "All"}, int(m.filter)))
+	if m.refreshing {
+		b.WriteString("  " + ui.Spinner(m.spinner) + ui.Faint.Render(" refreshing"))
+	}
 	b.WriteString("\n\n")
 
 	if len(m.filtered) == 0 {
@@ -636,7 +681,7 @@ func (m tskModel) viewList() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString(ui.RenderHelp("↑/↓ navigate", "→ open", "e edit", "tab filter", "q quit"))
+	b.WriteString(ui.RenderHelp("↑/↓ navigate", "→ open", "e edit", "r refresh", "tab filter", "q quit"))
 
 	return b.String()
 }
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameLine is one line of a file with the commit that last changed it.
+type BlameLine struct {
+	Hash     string // full hash; all zeros for uncommitted lines
+	OrigLine int    // line number in that commit's version of the file
+	Author   string
+	Time     time.Time
+	Summary  string
+	PrevHash string // the commit's parent that had the line's earlier version
+	PrevPath string // the file's path in PrevHash
+	Text     string
+}
+
+// Uncommitted reports whether the line has local changes not yet committed.
+func (l BlameLine) Uncommitted() bool { return strings.Trim(l.Hash, "0") == "" }
+
+// Blame annotates path as of rev, or the working tree when rev is "".
+func Blame(dir, rev, path string) []BlameLine {
+	args := []string{"blame", "--porcelain"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	out := gitOutput(dir, append(args, "--", path)...)
+
+	// Porcelain output gives a commit's details only the first time it
+	// appears; later lines refer back by hash.
+	commits := map[string]*BlameLine{}
+	var lines []BlameLine
+	var cur *BlameLine
+	for _, l := range strings.Split(out, "\n") {
+		if text, ok := strings.CutPrefix(l, "\t"); ok {
+			if cur != nil {
+				line := *cur
+				line.Text = text
+				lines = append(lines, line)
+			}
+			continue
+		}
+		key, value, _ := strings.Cut(l, " ")
+		if isHash(key) {
+			c, ok := commits[key]
+			if !ok {
+				c = &BlameLine{Hash: key}
+				commits[key] = c
+			}
+			orig, _, _ := strings.Cut(value, " ")
+			c.OrigLine, _ = strconv.Atoi(orig)
+			cur = c
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-time":
+			if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+				cur.Time = time.Unix(epoch, 0)
+			}
+		case "summary":
+			cur.Summary = value
+		case "previous":
+			cur.PrevHash, cur.PrevPath, _ = strings.Cut(value, " ")
+		}
+	}
+	return lines
+}
+
+// isHash reports whether s is a full SHA-1 or SHA-256 object name.
+func isHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	return strings.Trim(s, "0123456789abcdef") == ""
+}
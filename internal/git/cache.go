@@ -0,0 +1,110 @@
+package git
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Stamp records when a repo's index and HEAD last changed. A cached status
+// is current while its repo's stamp matches.
+type Stamp struct {
+	Index int64 // index mtime in the git dir, Unix nanoseconds (0 = missing)
+	Head  int64 // HEAD mtime
+}
+
+// ReadStamp stats dir's index and HEAD, in its git dir wherever .git points
+// (linked worktrees, submodules).
+func ReadStamp(dir string) Stamp {
+	_, gitDir, ok := FindRoot(dir)
+	if !ok {
+		return Stamp{}
+	}
+	mtime := func(name string) int64 {
+		fi, err := os.Stat(filepath.Join(gitDir, name))
+		if err != nil {
+			return 0
+		}
+		return fi.ModTime().UnixNano()
+	}
+	return Stamp{Index: mtime("index"), Head: mtime("HEAD")}
+}
+
+// StatusCache holds the last status read per repo path, persisted in
+// os.UserCacheDir()/lz/status.json between runs.
+type StatusCache map[string]RepoStatus
+
+func cachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lz", "status.json"), nil
+}
+
+// LoadStatusCache reads the cache. A missing or unreadable cache is empty.
+func LoadStatusCache() StatusCache {
+	c := StatusCache{}
+	path, err := cachePath()
+	if err != nil {
+		return c
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	if json.Unmarshal(data, &c) != nil {
+		return StatusCache{}
+	}
+	return c
+}
+
+// Lookup returns the cached status for dir. It is stale when the repo's
+// index or HEAD changed since it was read. Edits to tracked files don't
+// touch either, so even a current entry is a snapshot to revalidate.
+func (c StatusCache) Lookup(dir string) (s RepoStatus, ok, stale bool) {
+	s, ok = c[dir]
+	return s, ok, ok && s.Stamp != ReadStamp(dir)
+}
+
+// Put records a status. Failed reads are not cached.
+func (c StatusCache) Put(dir string, s RepoStatus) {
+	if s.Err == nil {
+		c[dir] = s
+	}
+}
+
+// Save writes the cache, dropping repos that no longer exist. The file is
+// replaced atomically, so concurrent runs never see a partial cache.
+func (c StatusCache) Save() error {
+	path, err := cachePath()
+	if err != nil {
+		return err
+	}
+	for dir := range c {
+		if _, err := os.Stat(dir); err != nil {
+			delete(c, dir)
+		}
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "status-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
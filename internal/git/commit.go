@@ -0,0 +1,196 @@
+package git
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitDetail holds a commit's metadata and the files it changed.
+type CommitDetail struct {
+	Hash       string // full hash
+	Author     string // "Name <email>"
+	AuthorTime time.Time
+	Committer  string
+	CommitTime time.Time
+	Parents    []string // short hashes; more than one for merges
+	Refs       []string // branches and tags pointing at the commit
+	Signature  byte     // git's %G? code: G, B, U, X, Y, R, E, or N for unsigned
+	Signer     string
+	Message    string // full message, subject first
+	Files      []FileChange
+}
+
+// IsMerge reports whether the commit has more than one parent.
+func (d CommitDetail) IsMerge() bool { return len(d.Parents) > 1 }
+
+// FileChange is one file touched by a commit, with line counts.
+type FileChange struct {
+	Status  byte   // A, M, D, R, C, T
+	Path    string // new path
+	OldPath string // previous path for renames and copies
+	Added   int
+	Deleted int
+	Binary  bool
+}
+
+// diffTree compares a commit with its first parent (or the empty tree for a
+// root commit), following renames.
+func diffTree(dir string, args ...string) string {
+	return gitOutput(dir, append([]string{"diff-tree", "-r", "-M", "--root", "--no-commit-id", "--diff-merges=first-parent"}, args...)...)
+}
+
+// GetCommitDetail returns the header and changed files of a commit.
+func GetCommitDetail(dir, hash string) CommitDetail {
+	var d CommitDetail
+	// Times in strict ISO 8601 keep the author's and committer's own zones.
+	const format = "%H%x00%an <%ae>%x00%aI%x00%cn <%ce>%x00%cI%x00%p%x00%D%x00%G?%x00%GS%x00%B"
+	parts := strings.SplitN(gitOutput(dir, "show", "-s", "--format="+format, hash), "\x00", 10)
+	if len(parts) < 10 {
+		return d
+	}
+	d.Hash, d.Author, d.Committer = parts[0], parts[1], parts[3]
+	d.AuthorTime, _ = time.Parse(time.RFC3339, parts[2])
+	d.CommitTime, _ = time.Parse(time.RFC3339, parts[4])
+	d.Parents = strings.Fields(parts[5])
+	if parts[6] != "" {
+		for _, ref := range strings.Split(parts[6], ", ") {
+			d.Refs = append(d.Refs, strings.TrimPrefix(ref, "tag: "))
+		}
+	}
+	if parts[7] != "" {
+		d.Signature = parts[7][0]
+	}
+	d.Signer = parts[8]
+	d.Message = strings.TrimRight(parts[9], "\n")
+
+	d.Files = changedFiles(dir, hash)
+	return d
+}
+
+// changedFiles pairs diff-tree's --name-status and --numstat output, which
+// list the same files in the same order.
+func changedFiles(dir, hash string) []FileChange {
+	names := strings.Split(diffTree(dir, "--name-status", "-z", hash), "\x00")
+	var files []FileChange
+	for i := 0; i+1 < len(names); i += 2 {
+		if names[i] == "" {
+			break
+		}
+		f := FileChange{Status: names[i][0], Path: names[i+1]}
+		if f.Status == 'R' || f.Status == 'C' {
+			if i+2 >= len(names) {
+				break
+			}
+			f.OldPath, f.Path = names[i+1], names[i+2]
+			i++
+		}
+		files = append(files, f)
+	}
+
+	// numstat -z: "added\tdeleted\tpath\0", or "added\tdeleted\t\0old\0new\0"
+	// for renames; binary files count as "-".
+	stats := strings.Split(diffTree(dir, "--numstat", "-z", hash), "\x00")
+	for i, n := 0, 0; i < len(stats) && n < len(files); i, n = i+1, n+1 {
+		fields := strings.SplitN(stats[i], "\t", 3)
+		if len(fields) < 3 {
+			break
+		}
+		if fields[2] == "" {
+			i += 2 // old and new paths follow
+		}
+		f := &files[n]
+		f.Binary = fields[0] == "-"
+		f.Added, _ = strconv.Atoi(fields[0])
+		f.Deleted, _ = strconv.Atoi(fields[1])
+	}
+	return files
+}
+
+// ShowCommitFile returns a commit's diff limited to one file. For renames,
+// oldPath keeps both sides of the diff.
+func ShowCommitFile(dir, hash, path, oldPath string) string {
+	args := []string{"-p", hash, "--", path}
+	if oldPath != "" {
+		args = append(args, oldPath)
+	}
+	return diffTree(dir, args...)
+}
+
+// FileCommit is a commit in a file's history, with the file's path there.
+type FileCommit struct {
+	Commit
+	Path    string
+	OldPath string // set when the commit renamed the file
+}
+
+// FileHistory returns up to n commits touching path, newest first,
+// following it across renames.
+func FileHistory(dir, path string, n int) []FileCommit {
+	out := gitOutput(dir, "log", "--follow", "--name-status", "--decorate-refs=refs/tags",
+		"--format=%x01%h%x00%s%x00%ct%x00%D", "-n", strconv.Itoa(n), "--", path)
+	var commits []FileCommit
+	for _, chunk := range strings.Split(out, "\x01")[1:] {
+		lines := strings.Split(strings.TrimRight(chunk, "\n"), "\n")
+		parts := strings.SplitN(lines[0], "\x00", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		c := FileCommit{Commit: Commit{Hash: parts[0], Subject: parts[1], Tag: tagFromRefs(parts[3])}, Path: path}
+		if epoch, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+			c.Time = time.Unix(epoch, 0)
+		}
+		// "M\tpath", or "R100\told\tnew" where the file was renamed; older
+		// commits then know it by the old name. Merges list no files.
+		for _, l := range lines[1:] {
+			fields := strings.Split(l, "\t")
+			if len(fields) < 2 {
+				continue
+			}
+			c.Path = fields[len(fields)-1]
+			if len(fields) == 3 {
+				c.OldPath = fields[1]
+			}
+		}
+		path = cmp.Or(c.OldPath, c.Path)
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// VerifyCommit checks that rev names a commit in dir.
+func VerifyCommit(dir, rev string) error {
+	if _, err := gitOutputErr(dir, "rev-parse", "--verify", "--quiet", "--end-of-options", rev+"^{commit}"); err != nil {
+		return fmt.Errorf("unknown revision %q", rev)
+	}
+	return nil
+}
+
+// TagBefore returns the tag nearest rev's first parent, so a tagged rev gets
+// its previous release. It is "" when there is none.
+func TagBefore(dir, rev string) string {
+	return gitLine(dir, "describe", "--tags", "--abbrev=0", "--end-of-options", rev+"^")
+}
+
+// CommitsBetween returns the non-merge commits in from..to with their full
+// messages, newest first. An empty from means all of to's history.
+func CommitsBetween(dir, from, to string) []Commit {
+	rng := to
+	if from != "" {
+		rng = from + ".." + to
+	}
+	out := gitOutput(dir, "log", "--no-merges", "--format=%x01%h%x00%s%x00%ct%x00%D%x00%b", rng, "--")
+	var commits []Commit
+	for _, chunk := range strings.Split(out, "\x01")[1:] {
+		parts := strings.SplitN(chunk, "\x00", 5)
+		if len(parts) < 5 {
+			continue
+		}
+		c, _ := parseCommit(strings.Join(parts[:4], "\x00"))
+		c.Body = strings.TrimSpace(parts[4])
+		commits = append(commits, c)
+	}
+	return commits
+}
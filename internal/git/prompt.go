@@ -0,0 +1,137 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Summary is the state a shell prompt shows, read with one git status.
+type Summary struct {
+	Branch      string // "" when Detached; mid-rebase, the branch being rebased
+	Detached    bool
+	Head        string // short HEAD hash ("" when Unborn)
+	Unborn      bool
+	HasUpstream bool
+	Ahead       int
+	Behind      int
+	Stashes     int
+	Dirty       bool   // changed or untracked files
+	Op          string // operation in progress, e.g. "rebase 2/5", "merge"
+}
+
+// FindRoot walks up from dir to the enclosing worktree and its git dir.
+// A .git file (linked worktrees, submodules) is followed to its gitdir.
+func FindRoot(dir string) (root, gitDir string, ok bool) {
+	for d := dir; ; d = filepath.Dir(d) {
+		p := filepath.Join(d, ".git")
+		if fi, err := os.Stat(p); err == nil {
+			if fi.IsDir() {
+				return d, p, true
+			}
+			if data, err := os.ReadFile(p); err == nil {
+				if g, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: "); found {
+					if !filepath.IsAbs(g) {
+						g = filepath.Join(d, g)
+					}
+					return d, g, true
+				}
+			}
+		}
+		if filepath.Dir(d) == d {
+			return "", "", false
+		}
+	}
+}
+
+// Summarize reads the repo enclosing dir. ok is false outside a repo.
+func Summarize(dir string) (s Summary, ok bool) {
+	root, gitDir, ok := FindRoot(dir)
+	if !ok {
+		return s, false
+	}
+	// --no-optional-locks: a prompt must not contend with the user's own
+	// git commands for index.lock.
+	out, err := gitOutputErr(root, "--no-optional-locks", "status", "--porcelain=v2", "--branch", "--show-stash", "-z")
+	if err != nil {
+		return s, false
+	}
+	for _, rec := range strings.Split(out, "\x00") {
+		header, isHeader := strings.CutPrefix(rec, "# ")
+		if !isHeader {
+			s.Dirty = s.Dirty || rec != ""
+			continue
+		}
+		key, val, _ := strings.Cut(header, " ")
+		switch key {
+		case "branch.oid":
+			if val == "(initial)" {
+				s.Unborn = true
+			} else {
+				s.Head = val[:min(7, len(val))]
+			}
+		case "branch.head":
+			if val == "(detached)" {
+				s.Detached = true
+			} else {
+				s.Branch = val
+			}
+		case "branch.upstream":
+			s.HasUpstream = true
+		case "branch.ab":
+			a, b, _ := strings.Cut(val, " ")
+			s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(a, "+"))
+			s.Behind, _ = strconv.Atoi(strings.TrimPrefix(b, "-"))
+		case "stash":
+			s.Stashes, _ = strconv.Atoi(val)
+		}
+	}
+	s.Op = InProgress(gitDir)
+	if s.Detached {
+		// Mid-rebase HEAD is detached; name the branch being rebased.
+		for _, d := range []string{"rebase-merge", "rebase-apply"} {
+			if ref, err := os.ReadFile(filepath.Join(gitDir, d, "head-name")); err == nil {
+				if b, ok := strings.CutPrefix(strings.TrimSpace(string(ref)), "refs/heads/"); ok {
+					s.Branch, s.Detached = b, false
+				}
+			}
+		}
+	}
+	return s, true
+}
+
+// InProgress names the operation a repo is in the middle of, from the
+// marker files git leaves in gitDir, or "" if none. Rebases include the
+// step, e.g. "rebase 2/5".
+func InProgress(gitDir string) string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+	step := func(dir, cur, end string) string {
+		c, _ := os.ReadFile(filepath.Join(gitDir, dir, cur))
+		e, _ := os.ReadFile(filepath.Join(gitDir, dir, end))
+		if n, t := strings.TrimSpace(string(c)), strings.TrimSpace(string(e)); n != "" && t != "" {
+			return " " + n + "/" + t
+		}
+		return ""
+	}
+	switch {
+	case exists("rebase-merge"):
+		return "rebase" + step("rebase-merge", "msgnum", "end")
+	case exists("rebase-apply/applying"):
+		return "am" + step("rebase-apply", "next", "last")
+	case exists("rebase-apply"):
+		return "rebase" + step("rebase-apply", "next", "last")
+	case exists("MERGE_HEAD"):
+		return "merge"
+	case exists("CHERRY_PICK_HEAD"):
+		return "cherry-pick"
+	case exists("REVERT_HEAD"):
+		return "revert"
+	case exists("BISECT_LOG"):
+		return "bisect"
+	}
+	return ""
+}
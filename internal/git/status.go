@@ -1,8 +1,14 @@
 package git
 
 import (
+	"bytes"
+	"cmp"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -10,16 +16,35 @@ import (
 
 // RepoStatus holds parsed git state for a single repo.
 type RepoStatus struct {
-	Branch      string
+	Branch      string // "" when Detached
+	Detached    bool
+	Head        string // short HEAD hash ("" when Unborn)
+	HeadRef     string // nearest ref when Detached, e.g. "v1.2.0" or "main~3"
+	Unborn      bool   // branch has no commits yet
+	Shallow     bool
 	Tag         string
 	TagAhead    int // commits ahead of tag (0 = HEAD is at tag)
 	Ahead       int
 	Behind      int
+	Base        string // default branch ref, e.g. "origin/main" ("" = unknown or same as upstream)
+	BaseAhead   int    // commits on HEAD not on Base
+	BaseBehind  int    // commits on Base not on HEAD
+	Remotes     []RemoteStatus
 	Stashes     []StashEntry
 	HasUpstream bool
 	Age         time.Time // last commit time
 	Files       []FileStatus
 	IsClean     bool
+	Err         error `json:"-"` // git failed on the repo; other fields are empty
+	Stamp       Stamp // index and HEAD mtimes as of the read, for StatusCache
+}
+
+// RemoteStatus is HEAD's divergence from the matching branch on one remote.
+type RemoteStatus struct {
+	Name   string // remote name, e.g. "upstream"
+	Ref    string // compared ref, e.g. "upstream/main"
+	Ahead  int
+	Behind int
 }
 
 // FileStatus is a single porcelain status entry.
@@ -30,13 +55,28 @@ type FileStatus struct {
 
 // GetStatus runs git commands and returns parsed status for a repo.
 func GetStatus(dir string) RepoStatus {
-	var s RepoStatus
+	// Stamped first, so a change during the read leaves the cache stale.
+	s := RepoStatus{Stamp: ReadStamp(dir)}
 
 	// branch
-	s.Branch = gitLine(dir, "branch", "--show-current")
+	branch, err := gitOutputErr(dir, "branch", "--show-current")
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	s.Branch = strings.TrimSpace(branch)
+	s.Head = gitLine(dir, "rev-parse", "--short", "--verify", "--quiet", "HEAD")
+	if s.Head == "" {
+		// No commits yet: nothing to describe or compare against.
+		s.Unborn = true
+		s.Files, s.IsClean = porcelainFiles(dir)
+		return s
+	}
 	if s.Branch == "" {
-		s.Branch = "HEAD"
+		s.Detached = true
+		s.HeadRef = nearestRef(dir)
 	}
+	s.Shallow = gitLine(dir, "rev-parse", "--is-shallow-repository") == "true"
 
 	// latest tag (full describe: "v1.0.0" or "v1.0.0-3-gabcdef")
 	if desc := gitLine(dir, "describe", "--tags"); desc != "" {
@@ -64,6 +104,20 @@ func GetStatus(dir string) RepoStatus {
 		}
 	}
 
+	// divergence from the default branch (skipped when it is the upstream)
+	base := DefaultBranch(dir)
+	if base != "" && base != upstream {
+		if v := gitLine(dir, "rev-list", "--left-right", "--count", base+"...HEAD"); v != "" {
+			if behind, ahead, ok := strings.Cut(v, "\t"); ok {
+				s.Base = base
+				s.BaseBehind, _ = strconv.Atoi(behind)
+				s.BaseAhead, _ = strconv.Atoi(ahead)
+			}
+		}
+	}
+
+	s.Remotes = remoteStatuses(dir, s.Branch, upstream, base)
+
 	// stash
 	stashOut := gitOutput(dir, "stash", "list", "--format=%gd%x00%s%x00%ct")
 	if stashOut != "" {
@@ -97,23 +151,162 @@ func GetStatus(dir string) RepoStatus {
 		}
 	}
 
-	// porcelain status
+	s.Files, s.IsClean = porcelainFiles(dir)
+
+	return s
+}
+
+// porcelainFiles parses `git status --porcelain` into file entries.
+func porcelainFiles(dir string) ([]FileStatus, bool) {
 	porcelain := gitOutput(dir, "status", "--porcelain")
 	if porcelain == "" {
-		s.IsClean = true
+		return nil, true
+	}
+	var files []FileStatus
+	for _, line := range strings.Split(strings.TrimRight(porcelain, "\n"), "\n") {
+		if len(line) < 3 {
+			continue
+		}
+		files = append(files, FileStatus{
+			XY:   line[:2],
+			File: line[3:],
+		})
+	}
+	return files, false
+}
+
+// Fingerprint summarizes the state lz g shows for a repo: HEAD, the
+// branch's upstream divergence, stashes, changed files and tags. It changes
+// when any of them does, and costs one git status, so watchers can poll it
+// and re-run GetStatus only on change.
+func Fingerprint(dir string) string {
+	var b strings.Builder
+	// --no-optional-locks: polling must not take index.lock from under the
+	// user's own git commands.
+	b.WriteString(gitOutput(dir, "--no-optional-locks", "status", "--porcelain=v2", "--branch", "--show-stash", "-z"))
+	// Tags don't show in status; their refs' mtimes stand in.
+	if _, gitDir, ok := FindRoot(dir); ok {
+		common := commonDir(gitDir)
+		for _, p := range []string{"packed-refs", "refs/tags"} {
+			if fi, err := os.Stat(filepath.Join(common, p)); err == nil {
+				fmt.Fprintf(&b, "\x00%s %d", p, fi.ModTime().UnixNano())
+			}
+		}
+	}
+	return b.String()
+}
+
+// commonDir is where a git dir's shared refs live: a linked worktree's git
+// dir names the main repo's in its commondir file.
+func commonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	d := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(d) {
+		d = filepath.Join(gitDir, d)
+	}
+	return d
+}
+
+// nearestRef names a detached HEAD by the closest ref: an exact tag, or a
+// path from a branch or tag like "main~3". Returns "" if nothing is near.
+func nearestRef(dir string) string {
+	if tag := gitLine(dir, "describe", "--tags", "--exact-match"); tag != "" {
+		return tag
+	}
+	name := gitLine(dir, "name-rev", "--name-only", "--no-undefined", "--exclude=*/HEAD", "HEAD")
+	name = strings.TrimSuffix(name, "^0")
+	for _, prefix := range []string{"tags/", "remotes/"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
+
+// DefaultBranch returns the repo's default branch as a revision, e.g.
+// "origin/main". The lz.defaultBranch git config wins; otherwise it is
+// read from origin/HEAD. Returns "" if neither is set.
+func DefaultBranch(dir string) string {
+	if v := Config(dir, "lz.defaultBranch"); v != "" {
+		return v
+	}
+	return gitLine(dir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+}
+
+// remoteStatuses compares HEAD against each tracked remote. Remotes come from
+// the space- or comma-separated lz.remotes git config; if unset, every remote
+// except the one the branch already tracks is used. Each remote is compared
+// against its branch of the same name, falling back to its default branch.
+// Refs already shown as the upstream or the default branch (base) are
+// skipped.
+func remoteStatuses(dir, branch, upstream, base string) []RemoteStatus {
+	all := strings.Fields(gitOutput(dir, "remote"))
+	var names []string
+	if v := Config(dir, "lz.remotes"); v != "" {
+		names = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
 	} else {
-		for _, line := range strings.Split(strings.TrimRight(porcelain, "\n"), "\n") {
-			if len(line) < 3 {
-				continue
+		for _, name := range all {
+			if !strings.HasPrefix(upstream, name+"/") {
+				names = append(names, name)
 			}
-			s.Files = append(s.Files, FileStatus{
-				XY:   line[:2],
-				File: line[3:],
-			})
 		}
 	}
 
-	return s
+	var out []RemoteStatus
+	for _, name := range names {
+		if !slices.Contains(all, name) {
+			continue
+		}
+		ref := remoteRef(dir, name, branch, branchName(base, all))
+		if ref == "" || ref == upstream || ref == base {
+			continue
+		}
+		v := gitLine(dir, "rev-list", "--left-right", "--count", ref+"...HEAD")
+		behind, ahead, ok := strings.Cut(v, "\t")
+		if !ok {
+			continue
+		}
+		rs := RemoteStatus{Name: name, Ref: ref}
+		rs.Behind, _ = strconv.Atoi(behind)
+		rs.Ahead, _ = strconv.Atoi(ahead)
+		out = append(out, rs)
+	}
+	return out
+}
+
+// branchName strips the remote from a ref like "upstream/release/2.x",
+// leaving "release/2.x". Refs not under one of remotes are returned as is.
+func branchName(ref string, remotes []string) string {
+	name := ref
+	for _, r := range remotes {
+		if rest, ok := strings.CutPrefix(ref, r+"/"); ok && len(rest) < len(name) {
+			name = rest
+		}
+	}
+	return name
+}
+
+// remoteRef picks the ref on remote to compare HEAD against: the branch of
+// the same name, the remote's HEAD, or the default branch (base, without
+// its remote) on it.
+func remoteRef(dir, remote, branch, base string) string {
+	var candidates []string
+	if branch != "" {
+		candidates = append(candidates, remote+"/"+branch)
+	}
+	if head := gitLine(dir, "symbolic-ref", "--quiet", "--short", "refs/remotes/"+remote+"/HEAD"); head != "" {
+		candidates = append(candidates, head)
+	}
+	if base != "" {
+		candidates = append(candidates, remote+"/"+base)
+	}
+	for _, ref := range candidates {
+		if gitLine(dir, "rev-parse", "--verify", "--quiet", "refs/remotes/"+ref) != "" {
+			return ref
+		}
+	}
+	return ""
 }
 
 // Diff returns the diff output for a single file in a repo.
@@ -148,47 +341,134 @@ type Commit struct {
 	Subject string    // first line of commit message
 	Time    time.Time // author time
 	Tag     string    // tag name if this commit is tagged
+
+	Body       string   // message after the subject (CommitsBetween only)
+	Graph      string   // lanes left of the commit (CommitGraph only)
+	GraphAfter []string // connector lines between this commit and the next
+}
+
+// LogFilter narrows a commit listing. The zero value matches all of HEAD.
+type LogFilter struct {
+	Author string // author name/email pattern; "me" means the repo's user.email
+	Since  string // any date git accepts: "2024-05-01", "1 week ago", "monday"
+	Until  string
+	Grep   string // commit message pattern, case-insensitive
+	Path   string // only commits touching this path (relative to the repo)
+	Range  string // revision range, e.g. "main..feature" (default HEAD)
+}
+
+// IsZero reports whether the filter matches everything.
+func (f LogFilter) IsZero() bool { return f == LogFilter{} }
+
+func (f LogFilter) args() []string {
+	var args []string
+	if f.Author != "" {
+		args = append(args, "--author="+f.Author)
+	}
+	if f.Since != "" {
+		args = append(args, "--since="+f.Since)
+	}
+	if f.Until != "" {
+		args = append(args, "--until="+f.Until)
+	}
+	if f.Grep != "" {
+		args = append(args, "--grep="+f.Grep, "--regexp-ignore-case")
+	}
+	if f.Range != "" {
+		args = append(args, f.Range)
+	}
+	if f.Path != "" {
+		args = append(args, "--", f.Path)
+	}
+	return args
 }
 
 // RecentCommits returns the last n commits for a repo.
 func RecentCommits(dir string, n int) []Commit {
-	out := gitOutput(dir, "log", "--decorate-refs=refs/tags", fmt.Sprintf("--format=%%h%%x00%%s%%x00%%ct%%x00%%D"), "-n", strconv.Itoa(n))
-	if out == "" {
-		return nil
+	return CommitPage(dir, LogFilter{}, false, 0, n)
+}
+
+// CommitPage returns up to n commits matching f after skipping the newest
+// skip, for paging through history. branches lists every local branch as
+// well as HEAD, unless f has a Range.
+func CommitPage(dir string, f LogFilter, branches bool, skip, n int) []Commit {
+	out := logOutput(dir, f, branches, "--skip", strconv.Itoa(skip), "-n", strconv.Itoa(n))
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if c, ok := parseCommit(line); ok {
+			commits = append(commits, c)
+		}
 	}
+	return commits
+}
+
+// CommitGraph returns the newest n commits matching f with git's --graph
+// lanes. Graphs don't page: a longer listing is a new call with a larger n.
+func CommitGraph(dir string, f LogFilter, branches bool, n int) []Commit {
+	out := logOutput(dir, f, branches, "--graph", "-n", strconv.Itoa(n))
 	var commits []Commit
 	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
-		parts := strings.SplitN(line, "\x00", 4)
-		if len(parts) < 4 {
+		graph, rest, ok := strings.Cut(line, "\x01")
+		if !ok {
+			if len(commits) > 0 {
+				last := &commits[len(commits)-1]
+				last.GraphAfter = append(last.GraphAfter, strings.TrimRight(graph, " "))
+			}
 			continue
 		}
-		var t time.Time
-		if epoch, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
-			t = time.Unix(epoch, 0)
-		}
-		var tag string
-		if parts[3] != "" {
-			for _, ref := range strings.Split(parts[3], ", ") {
-				ref = strings.TrimSpace(ref)
-				if strings.HasPrefix(ref, "tag: ") {
-					tag = strings.TrimPrefix(ref, "tag: ")
-					break
-				}
-			}
+		if c, ok := parseCommit(rest); ok {
+			c.Graph = strings.TrimRight(graph, " ")
+			commits = append(commits, c)
 		}
-		commits = append(commits, Commit{
-			Hash:    parts[0],
-			Subject: parts[1],
-			Time:    t,
-			Tag:     tag,
-		})
 	}
 	return commits
 }
 
-// ShowCommit returns the full diff output for a single commit.
+// logOutput runs git log for f with the fields parseCommit expects. Each
+// commit line starts with \x01 so graph lanes can be split off.
+func logOutput(dir string, f LogFilter, branches bool, args ...string) string {
+	if f.Author == "me" {
+		f.Author = cmp.Or(Config(dir, "user.email"), f.Author)
+	}
+	args = append([]string{"log", "--decorate-refs=refs/tags", "--format=%x01%h%x00%s%x00%ct%x00%D"}, args...)
+	// A range names its own revisions; branches would widen it.
+	if branches && f.Range == "" {
+		args = append(args, "HEAD", "--branches")
+	}
+	return gitOutput(dir, append(args, f.args()...)...)
+}
+
+func parseCommit(line string) (Commit, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(line, "\x01"), "\x00", 4)
+	if len(parts) < 4 {
+		return Commit{}, false
+	}
+	var t time.Time
+	if epoch, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+		t = time.Unix(epoch, 0)
+	}
+	return Commit{
+		Hash:    parts[0],
+		Subject: parts[1],
+		Time:    t,
+		Tag:     tagFromRefs(parts[3]),
+	}, true
+}
+
+// tagFromRefs picks the first tag from a %D decoration list.
+func tagFromRefs(refs string) string {
+	for _, ref := range strings.Split(refs, ", ") {
+		if tag, ok := strings.CutPrefix(strings.TrimSpace(ref), "tag: "); ok {
+			return tag
+		}
+	}
+	return ""
+}
+
+// ShowCommit returns the full diff output for a single commit. Merges are
+// diffed against their first parent, like the commit's file list.
 func ShowCommit(dir, hash string) string {
-	return gitOutput(dir, "show", hash)
+	return gitOutput(dir, "show", "--diff-merges=first-parent", hash)
 }
 
 // ShowStash returns the diff output for a stash entry.
@@ -196,6 +476,12 @@ func ShowStash(dir, index string) string {
 	return gitOutput(dir, "stash", "show", "-p", "stash@{"+index+"}")
 }
 
+// Config returns a git config value as seen from dir (repo, then global), or
+// "" if unset. lz reads its own settings from the "lz." section.
+func Config(dir, key string) string {
+	return gitLine(dir, "config", "--get", key)
+}
+
 func gitLine(dir string, args ...string) string {
 	return strings.TrimSpace(gitOutput(dir, args...))
 }
@@ -208,3 +494,26 @@ func gitOutput(dir string, args ...string) string {
 	}
 	return string(out)
 }
+
+// gitRun runs a git command that changes state, returning git's message on
+// failure.
+func gitRun(dir string, args ...string) error {
+	_, err := gitOutputErr(dir, args...)
+	return err
+}
+
+// gitOutputErr is gitOutput for callers that need to know why git failed:
+// the error carries git's own message.
+func gitOutputErr(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
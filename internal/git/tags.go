@@ -0,0 +1,67 @@
+package git
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagInfo is a tag with the commit it points at.
+type TagInfo struct {
+	Name    string
+	Time    time.Time // tagger date for annotated tags, else the commit's
+	Hash    string    // short hash of the tagged commit
+	Subject string    // tag message subject, or the commit's
+	Since   int       // commits on HEAD since the tag, once counted (CountSince)
+}
+
+// Tags lists a repo's tags, newest first, without Since.
+func Tags(dir string) []TagInfo {
+	out := gitOutput(dir, "for-each-ref", "--sort=-creatordate",
+		"--format=%(refname:short)%00%(creatordate:unix)%00%(*objectname:short)%00%(objectname:short)%00%(contents:subject)",
+		"refs/tags")
+	var tags []TagInfo
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		parts := strings.SplitN(line, "\x00", 5)
+		if len(parts) < 5 {
+			continue
+		}
+		t := TagInfo{Name: parts[0], Hash: cmp.Or(parts[2], parts[3]), Subject: parts[4]}
+		if epoch, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+			t.Time = time.Unix(epoch, 0)
+		}
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+// CountSince counts the commits on HEAD since each tag, by tag name. It runs
+// git once per tag, so it is slow for repos with many tags.
+func CountSince(dir string, tags []TagInfo) map[string]int {
+	counts := make(map[string]int, len(tags))
+	for _, t := range tags {
+		if n, err := strconv.Atoi(gitLine(dir, "rev-list", "--count", "refs/tags/"+t.Name+"..HEAD")); err == nil {
+			counts[t.Name] = n
+		}
+	}
+	return counts
+}
+
+// CreateTag makes an annotated tag at HEAD. The message is kept as given,
+// so Markdown headings survive.
+func CreateTag(dir, name, message string) error {
+	return gitRun(dir, "tag", "-a", "--cleanup=whitespace", name, "-m", message)
+}
+
+// PushTag pushes one tag to remote.
+func PushTag(dir, remote, name string) error {
+	return gitRun(dir, "push", remote, "refs/tags/"+name)
+}
+
+// PushRemote is the remote the current branch pushes to: its upstream's
+// remote, else "origin".
+func PushRemote(dir string) string {
+	branch := gitLine(dir, "symbolic-ref", "--short", "HEAD")
+	return cmp.Or(Config(dir, "branch."+branch+".remote"), "origin")
+}
@@ -0,0 +1,239 @@
+// Package prompt renders the one-line repo summary of lz g prompt and
+// lz-prompt. It uses only the standard library and internal/git, so a
+// binary built around it starts fast enough to run on every prompt.
+package prompt
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"aliz/lz/internal/git"
+)
+
+// A prompt is redrawn after every command, so each repo is read with a
+// single git status (git.Summarize) rather than GetStatus.
+
+const (
+	defaultTemplate          = "{branch}{dirty} {ahead}{behind} {stash} {op}"
+	defaultAggregateTemplate = "{repos} {dirty} {ahead}{behind} {stash} {op}"
+)
+
+// Options are the prompt flags.
+type Options struct {
+	Shell    string // escape for zsh, bash, fish or tmux; "" for plain text
+	Template string // {branch}-style fields; "" for the default
+	All      bool   // summarize every repo under dir
+}
+
+// ParseArgs reads --shell, --template and --all/-a.
+func ParseArgs(args []string) (Options, error) {
+	var o Options
+	for i := 0; i < len(args); i++ {
+		name, val, hasVal := strings.Cut(args[i], "=")
+		switch name {
+		case "--shell", "--template":
+			if !hasVal {
+				if i+1 >= len(args) {
+					return o, fmt.Errorf("%s needs a value", name)
+				}
+				i++
+				val = args[i]
+			}
+			if name == "--shell" {
+				o.Shell = val
+			} else {
+				o.Template = val
+			}
+		case "-a", "--all":
+			o.All = true
+		default:
+			return o, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return o, nil
+}
+
+// Run writes the prompt line for the repo enclosing dir, or with All for
+// every repo under dir. Outside a repo it writes nothing.
+func Run(w io.Writer, dir string, o Options) error {
+	sh := shell(o.Shell)
+	switch sh {
+	case "", "zsh", "bash", "fish", "tmux":
+	default:
+		return fmt.Errorf("--shell: want zsh, bash, fish or tmux, got %q", o.Shell)
+	}
+
+	var fields map[string]string
+	tmpl := cmp.Or(o.Template, defaultTemplate)
+	if o.All {
+		var err error
+		if fields, err = aggregateFields(dir, sh); err != nil {
+			return err
+		}
+		tmpl = cmp.Or(o.Template, defaultAggregateTemplate)
+	} else {
+		s, ok := git.Summarize(dir)
+		if !ok {
+			return nil // not in a repo: an empty segment
+		}
+		fields = summaryFields(s, sh)
+	}
+	_, err := fmt.Fprintln(w, render(tmpl, fields))
+	return err
+}
+
+// summaryFields maps template placeholders to painted values, using the
+// symbols of the status list. Empty values drop out of the template.
+func summaryFields(s git.Summary, sh shell) map[string]string {
+	branch := s.Branch
+	if s.Detached {
+		branch = s.Head
+	}
+	f := map[string]string{"branch": sh.paint("", branch)}
+	if s.Dirty {
+		f["branch"] = sh.paint("cyan", branch)
+		f["dirty"] = sh.paint("yellow", "*")
+	}
+	switch {
+	case !s.HasUpstream && !s.Detached && !s.Unborn:
+		f["ahead"] = sh.paint("faint", "∅")
+	case s.Ahead > 0:
+		f["ahead"] = sh.paint("green", "↑"+strconv.Itoa(s.Ahead))
+	}
+	if s.Behind > 0 {
+		f["behind"] = sh.paint("red", "↓"+strconv.Itoa(s.Behind))
+	}
+	if s.Stashes > 0 {
+		f["stash"] = sh.paint("", "≡"+strconv.Itoa(s.Stashes))
+	}
+	if s.Op != "" {
+		f["op"] = sh.paint("yellow", s.Op)
+	}
+	return f
+}
+
+// aggregateFields summarizes every repo under dir, for tmux status lines:
+// the repo count, how many are dirty, total ahead/behind and stashes, and
+// any operations in progress as "repo:op".
+func aggregateFields(dir string, sh shell) (map[string]string, error) {
+	repos, err := git.Discover(dir)
+	if err != nil {
+		return nil, err
+	}
+	sums := make([]git.Summary, len(repos))
+	oks := make([]bool, len(repos))
+	var wg sync.WaitGroup
+	wg.Add(len(repos))
+	for i, r := range repos {
+		go func() {
+			defer wg.Done()
+			sums[i], oks[i] = git.Summarize(r.Path)
+		}()
+	}
+	wg.Wait()
+
+	var n, dirty, ahead, behind, stashes int
+	var ops []string
+	for i, s := range sums {
+		if !oks[i] {
+			continue
+		}
+		n++
+		if s.Dirty {
+			dirty++
+		}
+		ahead += s.Ahead
+		behind += s.Behind
+		stashes += s.Stashes
+		if s.Op != "" {
+			ops = append(ops, repos[i].Name+":"+s.Op)
+		}
+	}
+
+	noun := "repos"
+	if n == 1 {
+		noun = "repo"
+	}
+	f := map[string]string{"repos": sh.paint("", fmt.Sprintf("%d %s", n, noun))}
+	if dirty > 0 {
+		f["dirty"] = sh.paint("cyan", strconv.Itoa(dirty)) + sh.paint("yellow", "*")
+	}
+	if ahead > 0 {
+		f["ahead"] = sh.paint("green", "↑"+strconv.Itoa(ahead))
+	}
+	if behind > 0 {
+		f["behind"] = sh.paint("red", "↓"+strconv.Itoa(behind))
+	}
+	if stashes > 0 {
+		f["stash"] = sh.paint("", "≡"+strconv.Itoa(stashes))
+	}
+	if len(ops) > 0 {
+		f["op"] = sh.paint("yellow", strings.Join(ops, ","))
+	}
+	return f, nil
+}
+
+var fieldRe = regexp.MustCompile(`\{(\w+)\}`)
+
+// render fills {name} placeholders. A word whose placeholders are all
+// empty is dropped with its literal text, so "{ahead} [{op}]" leaves no
+// stray spaces or brackets.
+func render(tmpl string, fields map[string]string) string {
+	var words []string
+	for _, w := range strings.Fields(tmpl) {
+		filled := false
+		out := fieldRe.ReplaceAllStringFunc(w, func(field string) string {
+			v := fields[field[1:len(field)-1]]
+			filled = filled || v != ""
+			return v
+		})
+		if filled || !fieldRe.MatchString(w) {
+			words = append(words, out)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// shell is the prompt language output is escaped for. "" prints plain text
+// without colors.
+type shell string
+
+var colors = map[string]struct{ ansi, tmux string }{
+	"green":  {"32", "fg=green"},
+	"red":    {"31", "fg=red"},
+	"cyan":   {"36", "fg=cyan"},
+	"yellow": {"33", "fg=yellow"},
+	"faint":  {"2", "dim"},
+}
+
+// paint colors text and escapes characters the shell would expand.
+// Non-printing sequences are wrapped so the shell measures the prompt
+// correctly: %{…%} in zsh, \001…\002 in bash (\[…\] is not honoured in
+// command substitution output).
+func (sh shell) paint(color, text string) string {
+	switch sh {
+	case "zsh":
+		text = strings.ReplaceAll(text, "%", "%%")
+	case "tmux":
+		text = strings.ReplaceAll(text, "#", "##")
+	}
+	c, ok := colors[color]
+	if !ok || sh == "" || text == "" {
+		return text
+	}
+	on, off := "\x1b["+c.ansi+"m", "\x1b[0m"
+	switch sh {
+	case "zsh":
+		return "%{" + on + "%}" + text + "%{" + off + "%}"
+	case "bash":
+		return "\x01" + on + "\x02" + text + "\x01" + off + "\x02"
+	case "tmux":
+		return "#[" + c.tmux + "]" + text + "#[default]"
+	}
+	return on + text + off
+}
@@ -0,0 +1,63 @@
+package prompt
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	fields := map[string]string{"branch": "main", "dirty": "*", "op": "merge"}
+	tests := []struct {
+		tmpl, want string
+	}{
+		{"{branch}{dirty} {ahead}{behind} {stash} {op}", "main* merge"},
+		{"{branch} [{stash}]", "main"},        // empty field drops its brackets
+		{"{branch} [{op}]", "main [merge]"},   // filled field keeps them
+		{"{ahead}{dirty}", "*"},               // one filled field keeps the word
+		{"on {branch}", "on main"},            // literal words stay
+		{"  {branch}   {op}  ", "main merge"}, // spacing is normalized
+		{"{unknown} {branch}", "main"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := render(tt.tmpl, fields); got != tt.want {
+			t.Errorf("render(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestPaint(t *testing.T) {
+	tests := []struct {
+		sh          shell
+		color, text string
+		want        string
+	}{
+		{"", "green", "↑1", "↑1"},
+		{"zsh", "", "100%", "100%%"},
+		{"zsh", "green", "a%b", "%{\x1b[32m%}a%%b%{\x1b[0m%}"},
+		{"bash", "red", "↓2", "\x01\x1b[31m\x02↓2\x01\x1b[0m\x02"},
+		{"bash", "", "a%b#c", "a%b#c"},
+		{"fish", "yellow", "*", "\x1b[33m*\x1b[0m"},
+		{"tmux", "", "fix#1", "fix##1"},
+		{"tmux", "cyan", "#main", "#[fg=cyan]##main#[default]"},
+		{"tmux", "faint", "∅", "#[dim]∅#[default]"},
+		{"zsh", "green", "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.sh.paint(tt.color, tt.text); got != tt.want {
+			t.Errorf("%q.paint(%q, %q) = %q, want %q", tt.sh, tt.color, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	o, err := ParseArgs([]string{"--shell", "zsh", "--template={branch}", "-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Options{Shell: "zsh", Template: "{branch}", All: true}); o != want {
+		t.Errorf("ParseArgs = %+v, want %+v", o, want)
+	}
+	for _, args := range [][]string{{"--shell"}, {"--bogus"}, {"--format={branch}"}} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("ParseArgs(%q): want an error", args)
+		}
+	}
+}
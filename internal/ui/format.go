@@ -58,6 +58,16 @@ func RenderTabBar(labels []string, active int) string {
 	return strings.Join(parts, " ")
 }
 
+// SpinnerInterval is how long each Spinner frame shows.
+const SpinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// Spinner renders frame n of a spinner for background work.
+func Spinner(n int) string {
+	return Faint.Render(string(spinnerFrames[n%len(spinnerFrames)]))
+}
+
 // Truncate shortens s to max display cells, appending "…" if truncated.
 // Unicode-safe via runewidth.
 func Truncate(s string, max int) string {
@@ -35,5 +35,9 @@ func printUsage() {
 	fmt.Println("lz — personal CLI toolkit")
 	fmt.Println()
 	fmt.Println("  lz t, lz tsk    task browser TUI [-l/--list] [-a/--all]")
-	fmt.Println("  lz g, lz git    multi-repo git status TUI [-l status] [-c commits] [-s stash]")
+	fmt.Println("  lz g, lz git    multi-repo git status TUI [-l status] [-c commits] [-s stash] [-n N] [-w watch] [--cached]")
+	fmt.Println("  lz g log        cross-repo commit timeline [--since D] [--author A] [--grep P]")
+	fmt.Println("  lz g report     Markdown activity report [--since D] [-o file]")
+	fmt.Println("  lz g changelog  Markdown changelog since the last tag [repo] [--from tag] [--to ref]")
+	fmt.Println("  lz g prompt     one-line repo summary for shell prompts [--shell zsh|bash|fish|tmux] [--template T] [--all]")
 }